@@ -0,0 +1,409 @@
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ashleybill/srclient"
+)
+
+const registryContentType = "application/vnd.schemaregistry.v1+json"
+
+// registryClient calls the registry endpoints, and query parameters, that srclient doesn't support. It shares the
+// URL and credentials the provider configures srclient with.
+type registryClient struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// RegistryError is an error response from the registry
+type RegistryError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *RegistryError) Error() string {
+	return fmt.Sprintf("error code %d: %s", e.Code, e.Message)
+}
+
+// isNotFound reports whether err is the registry not finding a subject, version or schema, as returned by either
+// client
+func isNotFound(err error) bool {
+	var registryErr *RegistryError
+	if errors.As(err, &registryErr) {
+		return registryErr.StatusCode == http.StatusNotFound
+	}
+
+	var srclientErr srclient.Error
+	if errors.As(err, &srclientErr) {
+		return srclientErr.Code == http.StatusNotFound || srclientErr.Code/100 == http.StatusNotFound
+	}
+
+	return false
+}
+
+// isConflict reports whether err is the registry rejecting a schema as incompatible
+func isConflict(err error) bool {
+	var registryErr *RegistryError
+	return errors.As(err, &registryErr) && registryErr.StatusCode == http.StatusConflict
+}
+
+func isAccessDenied(err error) bool {
+	var registryErr *RegistryError
+	return errors.As(err, &registryErr) && (registryErr.StatusCode == http.StatusUnauthorized || registryErr.StatusCode == http.StatusForbidden)
+}
+
+// SchemaRequest is the body of register, lookup and compatibility requests
+type SchemaRequest struct {
+	Schema     string               `json:"schema"`
+	SchemaType string               `json:"schemaType,omitempty"`
+	References []srclient.Reference `json:"references,omitempty"`
+	Metadata   *Metadata            `json:"metadata,omitempty"`
+	RuleSet    *RuleSet             `json:"ruleSet,omitempty"`
+}
+
+// SchemaResponse is a schema as returned by the subject endpoints
+type SchemaResponse struct {
+	Subject    string               `json:"subject"`
+	ID         int                  `json:"id"`
+	Version    int                  `json:"version"`
+	Schema     string               `json:"schema"`
+	SchemaType string               `json:"schemaType"`
+	References []srclient.Reference `json:"references"`
+	Metadata   *Metadata            `json:"metadata"`
+	RuleSet    *RuleSet             `json:"ruleSet"`
+}
+
+// Metadata is the data contract metadata of a schema: tags of the fields at a path, free-form properties, and which
+// of the properties are sensitive
+type Metadata struct {
+	Tags       map[string][]string `json:"tags,omitempty"`
+	Properties map[string]string   `json:"properties,omitempty"`
+	Sensitive  []string            `json:"sensitive,omitempty"`
+}
+
+// RuleSet is the data contract rules of a schema: domain rules run when data is written or read, migration rules
+// when it is read as another version
+type RuleSet struct {
+	MigrationRules []Rule `json:"migrationRules,omitempty"`
+	DomainRules    []Rule `json:"domainRules,omitempty"`
+}
+
+// Rule is a data contract rule
+type Rule struct {
+	Name      string            `json:"name"`
+	Kind      string            `json:"kind"`
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Tags      []string          `json:"tags,omitempty"`
+	Params    map[string]string `json:"params,omitempty"`
+	Expr      string            `json:"expr,omitempty"`
+	OnSuccess string            `json:"onSuccess,omitempty"`
+	OnFailure string            `json:"onFailure,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+}
+
+// SubjectVersion is a version of a subject a schema is registered as
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// SubjectConfig is the config of a subject, as returned by the config endpoints
+type SubjectConfig struct {
+	CompatibilityLevel string    `json:"compatibilityLevel,omitempty"`
+	CompatibilityGroup string    `json:"compatibilityGroup,omitempty"`
+	ValidateFields     *bool     `json:"validateFields,omitempty"`
+	Normalize          *bool     `json:"normalize,omitempty"`
+	Alias              string    `json:"alias,omitempty"`
+	DefaultMetadata    *Metadata `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   *Metadata `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     *RuleSet  `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    *RuleSet  `json:"overrideRuleSet,omitempty"`
+}
+
+// subjectConfigRequest is the body of config updates, which names the compatibility level differently
+type subjectConfigRequest struct {
+	Compatibility      string    `json:"compatibility,omitempty"`
+	CompatibilityGroup string    `json:"compatibilityGroup,omitempty"`
+	ValidateFields     *bool     `json:"validateFields,omitempty"`
+	Normalize          *bool     `json:"normalize,omitempty"`
+	Alias              string    `json:"alias,omitempty"`
+	DefaultMetadata    *Metadata `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   *Metadata `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     *RuleSet  `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    *RuleSet  `json:"overrideRuleSet,omitempty"`
+}
+
+type registerSchemaResponse struct {
+	ID int `json:"id"`
+}
+
+type modeResponse struct {
+	Mode string `json:"mode"`
+}
+
+type compatibilityResponse struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+func newRegistryClient(registryURL string, username string, password string) *registryClient {
+	return &registryClient{
+		url:        strings.TrimSuffix(registryURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NewSchemaRequest builds a SchemaRequest, leaving out the schema type for Avro like srclient does
+func NewSchemaRequest(schemaString string, schemaType srclient.SchemaType, references []srclient.Reference) SchemaRequest {
+	return SchemaRequest{
+		Schema:     schemaString,
+		SchemaType: schemaType.String(),
+		References: references,
+	}
+}
+
+// RegisterSchema registers a schema under a subject, returning the ID of the new or existing schema
+func (c *registryClient) RegisterSchema(ctx context.Context, subject string, request SchemaRequest, normalize bool) (int, error) {
+	query := url.Values{}
+	if normalize {
+		query.Set("normalize", "true")
+	}
+
+	var response registerSchemaResponse
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)), query, request, &response); err != nil {
+		return 0, err
+	}
+
+	return response.ID, nil
+}
+
+// LookupSchema finds the version of a subject a schema is registered as
+func (c *registryClient) LookupSchema(ctx context.Context, subject string, request SchemaRequest, normalize bool) (*SchemaResponse, error) {
+	query := url.Values{}
+	if normalize {
+		query.Set("normalize", "true")
+	}
+
+	var response SchemaResponse
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/subjects/%s", url.PathEscape(subject)), query, request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// TestCompatibility checks a schema against a version ("latest" or a version number) of a subject, returning the
+// registry's explanation of any incompatibility
+func (c *registryClient) TestCompatibility(ctx context.Context, subject string, version string, request SchemaRequest, normalize bool) (bool, []string, error) {
+	query := url.Values{}
+	query.Set("verbose", "true")
+	if normalize {
+		query.Set("normalize", "true")
+	}
+
+	var response compatibilityResponse
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/compatibility/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)), query, request, &response); err != nil {
+		return false, nil, err
+	}
+
+	return response.IsCompatible, response.Messages, nil
+}
+
+// GetVersions lists the versions of a subject, including soft deleted ones when deleted is set
+func (c *registryClient) GetVersions(ctx context.Context, subject string, deleted bool) ([]int, error) {
+	query := url.Values{}
+	if deleted {
+		query.Set("deleted", "true")
+	}
+
+	var versions []int
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)), query, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetSchemaByVersion gets a version ("latest" or a version number) of a subject
+func (c *registryClient) GetSchemaByVersion(ctx context.Context, subject string, version string) (*SchemaResponse, error) {
+	var response SchemaResponse
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)), nil, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetSubjectVersionsByID lists the subject versions a schema ID is registered as
+func (c *registryClient) GetSubjectVersionsByID(ctx context.Context, id int) ([]SubjectVersion, error) {
+	var versions []SubjectVersion
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/schemas/ids/%d/versions", id), nil, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetReferencedBy lists the IDs of the schemas that reference a version of a subject
+func (c *registryClient) GetReferencedBy(ctx context.Context, subject string, version int) ([]int, error) {
+	var ids []int
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/subjects/%s/versions/%d/referencedby", url.PathEscape(subject), version), nil, nil, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// DeleteSubject deletes every version of a subject, returning the versions deleted. A permanent delete only removes
+// versions that are already soft deleted.
+func (c *registryClient) DeleteSubject(ctx context.Context, subject string, permanent bool) ([]int, error) {
+	query := url.Values{}
+	if permanent {
+		query.Set("permanent", "true")
+	}
+
+	var versions []int
+	if err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/subjects/%s", url.PathEscape(subject)), query, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DeleteVersion deletes a version of a subject. A permanent delete only removes a version that is already soft deleted.
+func (c *registryClient) DeleteVersion(ctx context.Context, subject string, version int, permanent bool) error {
+	query := url.Values{}
+	if permanent {
+		query.Set("permanent", "true")
+	}
+
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/subjects/%s/versions/%d", url.PathEscape(subject), version), query, nil, nil)
+}
+
+// GetSubjectConfig gets the config of a subject. With defaultToGlobal, fields the subject doesn't set come from the
+// global config, otherwise a subject without a config of its own is not found.
+func (c *registryClient) GetSubjectConfig(ctx context.Context, subject string, defaultToGlobal bool) (*SubjectConfig, error) {
+	query := url.Values{}
+	if defaultToGlobal {
+		query.Set("defaultToGlobal", "true")
+	}
+
+	var config SubjectConfig
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/config/%s", url.PathEscape(subject)), query, nil, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// UpdateSubjectConfig sets the fields of the config of a subject that config sets, leaving the others as they are
+func (c *registryClient) UpdateSubjectConfig(ctx context.Context, subject string, config SubjectConfig) error {
+	request := subjectConfigRequest{
+		Compatibility:      config.CompatibilityLevel,
+		CompatibilityGroup: config.CompatibilityGroup,
+		ValidateFields:     config.ValidateFields,
+		Normalize:          config.Normalize,
+		Alias:              config.Alias,
+		DefaultMetadata:    config.DefaultMetadata,
+		OverrideMetadata:   config.OverrideMetadata,
+		DefaultRuleSet:     config.DefaultRuleSet,
+		OverrideRuleSet:    config.OverrideRuleSet,
+	}
+
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/config/%s", url.PathEscape(subject)), nil, request, nil)
+}
+
+// DeleteSubjectConfig deletes the config of a subject, which falls back to the global config
+func (c *registryClient) DeleteSubjectConfig(ctx context.Context, subject string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/config/%s", url.PathEscape(subject)), nil, nil, nil)
+}
+
+// GetSubjectMode gets the mode of a subject, such as READWRITE or READONLY. With defaultToGlobal, a subject without a
+// mode of its own has the global mode, otherwise it is not found.
+func (c *registryClient) GetSubjectMode(ctx context.Context, subject string, defaultToGlobal bool) (string, error) {
+	query := url.Values{}
+	if defaultToGlobal {
+		query.Set("defaultToGlobal", "true")
+	}
+
+	var response modeResponse
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/mode/%s", url.PathEscape(subject)), query, nil, &response); err != nil {
+		return "", err
+	}
+
+	return response.Mode, nil
+}
+
+func (c *registryClient) do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	endpoint := c.url + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var payload io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payload)
+	if err != nil {
+		return err
+	}
+	contentType := registryContentType
+	if strings.HasPrefix(path, catalogPathPrefix) {
+		contentType = catalogContentType
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		registryErr := &RegistryError{StatusCode: resp.StatusCode}
+		if err = json.Unmarshal(respBody, registryErr); err != nil || registryErr.Code == 0 {
+			registryErr.Code = resp.StatusCode
+			registryErr.Message = strings.TrimSpace(string(respBody))
+			if registryErr.Message == "" {
+				registryErr.Message = resp.Status
+			}
+		}
+		return registryErr
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,152 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// catalogPathPrefix is where the registry serves the catalog API, which takes plain JSON
+const (
+	catalogPathPrefix  = "/catalog/"
+	catalogContentType = "application/json"
+)
+
+// TagDef is the definition of a catalog tag
+type TagDef struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	EntityTypes []string `json:"entityTypes,omitempty"`
+}
+
+// TagBinding is a catalog tag applied to an entity, such as a schema, a record or a field
+type TagBinding struct {
+	TypeName   string `json:"typeName"`
+	EntityType string `json:"entityType"`
+	EntityName string `json:"entityName"`
+}
+
+// CreateTagDef creates the definition of a tag
+func (c *registryClient) CreateTagDef(ctx context.Context, tagDef TagDef) error {
+	return c.do(ctx, http.MethodPost, "/catalog/v1/types/tagdefs", nil, []TagDef{tagDef}, nil)
+}
+
+// GetTagDef gets the definition of a tag
+func (c *registryClient) GetTagDef(ctx context.Context, name string) (*TagDef, error) {
+	var tagDef TagDef
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/catalog/v1/types/tagdefs/%s", url.PathEscape(name)), nil, nil, &tagDef); err != nil {
+		return nil, err
+	}
+
+	return &tagDef, nil
+}
+
+// UpdateTagDef replaces the description and entity types of a tag
+func (c *registryClient) UpdateTagDef(ctx context.Context, tagDef TagDef) error {
+	return c.do(ctx, http.MethodPut, "/catalog/v1/types/tagdefs", nil, []TagDef{tagDef}, nil)
+}
+
+// DeleteTagDef deletes the definition of a tag
+func (c *registryClient) DeleteTagDef(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/catalog/v1/types/tagdefs/%s", url.PathEscape(name)), nil, nil, nil)
+}
+
+// CreateTagBinding applies a tag to an entity
+func (c *registryClient) CreateTagBinding(ctx context.Context, binding TagBinding) error {
+	return c.do(ctx, http.MethodPost, "/catalog/v1/entity/tags", nil, []TagBinding{binding}, nil)
+}
+
+// GetTagBindings gets the tags applied to an entity
+func (c *registryClient) GetTagBindings(ctx context.Context, entityType string, entityName string) ([]TagBinding, error) {
+	var bindings []TagBinding
+	if err := c.do(ctx, http.MethodGet, catalogEntityPath(entityType, entityName, "tags"), nil, nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// DeleteTagBinding removes a tag from an entity
+func (c *registryClient) DeleteTagBinding(ctx context.Context, binding TagBinding) error {
+	return c.do(ctx, http.MethodDelete, catalogEntityPath(binding.EntityType, binding.EntityName, "tags/"+url.PathEscape(binding.TypeName)), nil, nil, nil)
+}
+
+func catalogEntityPath(entityType string, entityName string, operation string) string {
+	return fmt.Sprintf("/catalog/v1/entity/type/%s/name/%s/%s", url.PathEscape(entityType), url.PathEscape(entityName), operation)
+}
+
+// BusinessMetadataDef is the definition of business metadata: a named set of attributes entities can have
+type BusinessMetadataDef struct {
+	Name          string                         `json:"name"`
+	Description   string                         `json:"description,omitempty"`
+	AttributeDefs []BusinessMetadataAttributeDef `json:"attributeDefs"`
+}
+
+// BusinessMetadataAttributeDef is an attribute of business metadata. Its options say which entity types it applies
+// to, as a JSON list, and the longest value it takes.
+type BusinessMetadataAttributeDef struct {
+	Name        string            `json:"name"`
+	TypeName    string            `json:"typeName"`
+	IsOptional  bool              `json:"isOptional"`
+	Cardinality string            `json:"cardinality,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// BusinessMetadataBinding is business metadata applied to an entity, with the values of its attributes
+type BusinessMetadataBinding struct {
+	TypeName   string            `json:"typeName"`
+	EntityType string            `json:"entityType"`
+	EntityName string            `json:"entityName"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// CreateBusinessMetadataDef creates the definition of business metadata
+func (c *registryClient) CreateBusinessMetadataDef(ctx context.Context, def BusinessMetadataDef) error {
+	return c.do(ctx, http.MethodPost, "/catalog/v1/types/businessmetadatadefs", nil, []BusinessMetadataDef{def}, nil)
+}
+
+// GetBusinessMetadataDef gets the definition of business metadata
+func (c *registryClient) GetBusinessMetadataDef(ctx context.Context, name string) (*BusinessMetadataDef, error) {
+	var def BusinessMetadataDef
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/catalog/v1/types/businessmetadatadefs/%s", url.PathEscape(name)), nil, nil, &def); err != nil {
+		return nil, err
+	}
+
+	return &def, nil
+}
+
+// UpdateBusinessMetadataDef replaces the description and attributes of business metadata
+func (c *registryClient) UpdateBusinessMetadataDef(ctx context.Context, def BusinessMetadataDef) error {
+	return c.do(ctx, http.MethodPut, "/catalog/v1/types/businessmetadatadefs", nil, []BusinessMetadataDef{def}, nil)
+}
+
+// DeleteBusinessMetadataDef deletes the definition of business metadata
+func (c *registryClient) DeleteBusinessMetadataDef(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/catalog/v1/types/businessmetadatadefs/%s", url.PathEscape(name)), nil, nil, nil)
+}
+
+// CreateBusinessMetadataBinding applies business metadata to an entity
+func (c *registryClient) CreateBusinessMetadataBinding(ctx context.Context, binding BusinessMetadataBinding) error {
+	return c.do(ctx, http.MethodPost, "/catalog/v1/entity/businessmetadata", nil, []BusinessMetadataBinding{binding}, nil)
+}
+
+// UpdateBusinessMetadataBinding replaces the attribute values of business metadata applied to an entity
+func (c *registryClient) UpdateBusinessMetadataBinding(ctx context.Context, binding BusinessMetadataBinding) error {
+	return c.do(ctx, http.MethodPut, "/catalog/v1/entity/businessmetadata", nil, []BusinessMetadataBinding{binding}, nil)
+}
+
+// GetBusinessMetadataBindings gets the business metadata applied to an entity
+func (c *registryClient) GetBusinessMetadataBindings(ctx context.Context, entityType string, entityName string) ([]BusinessMetadataBinding, error) {
+	var bindings []BusinessMetadataBinding
+	if err := c.do(ctx, http.MethodGet, catalogEntityPath(entityType, entityName, "businessmetadata"), nil, nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// DeleteBusinessMetadataBinding removes business metadata from an entity
+func (c *registryClient) DeleteBusinessMetadataBinding(ctx context.Context, binding BusinessMetadataBinding) error {
+	return c.do(ctx, http.MethodDelete, catalogEntityPath(binding.EntityType, binding.EntityName, "businessmetadata/"+url.PathEscape(binding.TypeName)), nil, nil, nil)
+}
@@ -0,0 +1,99 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Exporter is a schema exporter, which copies subjects to another registry
+type Exporter struct {
+	Name                string            `json:"name,omitempty"`
+	ContextType         string            `json:"contextType,omitempty"`
+	Context             string            `json:"context,omitempty"`
+	Subjects            []string          `json:"subjects,omitempty"`
+	SubjectRenameFormat string            `json:"subjectRenameFormat,omitempty"`
+	Config              map[string]string `json:"config,omitempty"`
+}
+
+// ExporterStatus is where an exporter is at
+type ExporterStatus struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Offset int64  `json:"offset"`
+	Ts     int64  `json:"ts"`
+	Trace  string `json:"trace"`
+}
+
+// CreateExporter creates an exporter, which starts running
+func (c *registryClient) CreateExporter(ctx context.Context, exporter Exporter) error {
+	return c.do(ctx, http.MethodPost, "/exporters", nil, exporter, nil)
+}
+
+// GetExporter gets the definition of an exporter, including its config
+func (c *registryClient) GetExporter(ctx context.Context, name string) (*Exporter, error) {
+	var exporter Exporter
+	if err := c.do(ctx, http.MethodGet, exporterPath(name, ""), nil, nil, &exporter); err != nil {
+		return nil, err
+	}
+
+	return &exporter, nil
+}
+
+// UpdateExporter replaces the definition of an exporter
+func (c *registryClient) UpdateExporter(ctx context.Context, exporter Exporter) error {
+	name := exporter.Name
+	exporter.Name = ""
+
+	return c.do(ctx, http.MethodPut, exporterPath(name, ""), nil, exporter, nil)
+}
+
+// DeleteExporter deletes an exporter, leaving what it exported in the other registry
+func (c *registryClient) DeleteExporter(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, exporterPath(name, ""), nil, nil, nil)
+}
+
+// GetExporterStatus gets the state of an exporter and the offset it is at
+func (c *registryClient) GetExporterStatus(ctx context.Context, name string) (*ExporterStatus, error) {
+	var status ExporterStatus
+	if err := c.do(ctx, http.MethodGet, exporterPath(name, "status"), nil, nil, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// GetExporterConfig gets the config an exporter connects to the destination registry with
+func (c *registryClient) GetExporterConfig(ctx context.Context, name string) (map[string]string, error) {
+	var config map[string]string
+	if err := c.do(ctx, http.MethodGet, exporterPath(name, "config"), nil, nil, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// PauseExporter stops an exporter where it is at
+func (c *registryClient) PauseExporter(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodPut, exporterPath(name, "pause"), nil, nil, nil)
+}
+
+// ResumeExporter starts a paused exporter from where it stopped
+func (c *registryClient) ResumeExporter(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodPut, exporterPath(name, "resume"), nil, nil, nil)
+}
+
+// ResetExporter makes a paused exporter start over from the first schema when it resumes
+func (c *registryClient) ResetExporter(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodPut, exporterPath(name, "reset"), nil, nil, nil)
+}
+
+func exporterPath(name string, operation string) string {
+	path := fmt.Sprintf("/exporters/%s", url.PathEscape(name))
+	if operation != "" {
+		path += "/" + operation
+	}
+
+	return path
+}
@@ -0,0 +1,66 @@
+package schemaregistry
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServerClusterID is the cluster a registry belongs to, as returned by /v1/metadata/id
+type ServerClusterID struct {
+	Scope struct {
+		Path     []string          `json:"path"`
+		Clusters map[string]string `json:"clusters"`
+	} `json:"scope"`
+}
+
+// ServerVersion is the version of a registry, as returned by /v1/metadata/version
+type ServerVersion struct {
+	Version  string `json:"version"`
+	CommitID string `json:"commitId"`
+}
+
+// The clusters the scope of a registry names
+const (
+	schemaRegistryClusterKey = "schema-registry-cluster"
+	kafkaClusterKey          = "kafka-cluster"
+)
+
+// GetClusterID gets the cluster the registry belongs to
+func (c *registryClient) GetClusterID(ctx context.Context) (*ServerClusterID, error) {
+	var clusterID ServerClusterID
+	if err := c.do(ctx, http.MethodGet, "/v1/metadata/id", nil, nil, &clusterID); err != nil {
+		return nil, err
+	}
+
+	return &clusterID, nil
+}
+
+// GetServerVersion gets the version of the registry
+func (c *registryClient) GetServerVersion(ctx context.Context) (*ServerVersion, error) {
+	var version ServerVersion
+	if err := c.do(ctx, http.MethodGet, "/v1/metadata/version", nil, nil, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetSchemaTypes gets the schema types the registry supports, such as AVRO, JSON and PROTOBUF
+func (c *registryClient) GetSchemaTypes(ctx context.Context) ([]string, error) {
+	var schemaTypes []string
+	if err := c.do(ctx, http.MethodGet, "/schemas/types", nil, nil, &schemaTypes); err != nil {
+		return nil, err
+	}
+
+	return schemaTypes, nil
+}
+
+// GetContexts gets the contexts the registry has subjects in, starting with the default context "."
+func (c *registryClient) GetContexts(ctx context.Context) ([]string, error) {
+	var contexts []string
+	if err := c.do(ctx, http.MethodGet, "/contexts", nil, nil, &contexts); err != nil {
+		return nil, err
+	}
+
+	return contexts, nil
+}
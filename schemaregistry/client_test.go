@@ -0,0 +1,126 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashleybill/srclient"
+)
+
+func TestRegistryClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/missing/versions":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Subject 'missing' not found."}`))
+		case "/subjects/broken/versions":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`upstream unavailable`))
+		default:
+			w.Write([]byte(`[1,2]`))
+		}
+	}))
+	defer server.Close()
+
+	registry := newRegistryClient(server.URL, "", "")
+
+	versions, err := registry.GetVersions(context.Background(), "present", false)
+	if err != nil || len(versions) != 2 {
+		t.Errorf("expected versions [1 2], got %v (%v)", versions, err)
+	}
+
+	_, err = registry.GetVersions(context.Background(), "missing", false)
+	var registryErr *RegistryError
+	if !errors.As(err, &registryErr) || registryErr.Code != 40401 || registryErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 40401 registry error, got %v", err)
+	}
+	if !isNotFound(err) {
+		t.Errorf("expected %v to be not found", err)
+	}
+
+	_, err = registry.GetVersions(context.Background(), "broken", false)
+	if !errors.As(err, &registryErr) || registryErr.Code != http.StatusInternalServerError || registryErr.Message != "upstream unavailable" {
+		t.Errorf("expected the status code and body as the error, got %v", err)
+	}
+	if isNotFound(err) {
+		t.Errorf("expected %v not to be not found", err)
+	}
+}
+
+func TestIsNotFoundSrclient(t *testing.T) {
+	if !isNotFound(srclient.Error{Code: 40403}) {
+		t.Error("expected srclient error 40403 to be not found")
+	}
+
+	if isNotFound(srclient.Error{Code: 40901}) {
+		t.Error("expected srclient error 40901 not to be not found")
+	}
+}
+
+func TestRegistryClientUpdateSubjectConfig(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/config/payments-value" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		payload, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(payload, &body); err != nil {
+			t.Errorf("invalid request body %s: %v", payload, err)
+		}
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	registry := newRegistryClient(server.URL, "", "")
+
+	err := registry.UpdateSubjectConfig(context.Background(), "payments-value", SubjectConfig{
+		CompatibilityLevel: "FULL",
+		OverrideMetadata:   &Metadata{Tags: map[string][]string{"**.ssn": {"PII"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Updates name the compatibility level differently than the config the registry returns
+	if body["compatibility"] != "FULL" || body["compatibilityLevel"] != nil {
+		t.Errorf("expected the level as compatibility, got %v", body)
+	}
+	if _, ok := body["overrideMetadata"]; !ok {
+		t.Errorf("expected the override metadata, got %v", body)
+	}
+	if _, ok := body["defaultMetadata"]; ok {
+		t.Errorf("expected fields that aren't set to be left out, got %v", body)
+	}
+}
+
+func TestRegistryClientGetSubjectMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mode/payments-value" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("defaultToGlobal") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40409,"message":"Subject 'payments-value' does not have subject-level mode configured"}`))
+			return
+		}
+		w.Write([]byte(`{"mode":"READWRITE"}`))
+	}))
+	defer server.Close()
+
+	registry := newRegistryClient(server.URL, "", "")
+
+	mode, err := registry.GetSubjectMode(context.Background(), "payments-value", true)
+	if err != nil || mode != "READWRITE" {
+		t.Errorf("expected the global mode READWRITE, got %q (%v)", mode, err)
+	}
+
+	// A subject without a mode of its own has none unless it defaults to the global mode
+	if _, err = registry.GetSubjectMode(context.Background(), "payments-value", false); !isNotFound(err) {
+		t.Errorf("expected the subject's own mode to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,388 @@
+package compatibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true, "double": true, "bytes": true, "string": true,
+}
+
+// avroPromotions lists the writer types each reader type can be promoted from, per the Avro schema resolution rules
+var avroPromotions = map[string][]string{
+	"long":   {"int"},
+	"float":  {"int", "long"},
+	"double": {"int", "long", "float"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+type avroSchema struct {
+	kind      string
+	name      string
+	aliases   []string
+	fields    []avroField
+	symbols   []string
+	enumDflt  bool
+	items     *avroSchema
+	values    *avroSchema
+	branches  []*avroSchema
+	size      int
+	reference string
+}
+
+type avroField struct {
+	name       string
+	aliases    []string
+	schema     *avroSchema
+	hasDefault bool
+}
+
+type avroParser struct {
+	named map[string]*avroSchema
+}
+
+func parseAvro(schema string) (*avroSchema, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schema), &v); err != nil {
+		return nil, fmt.Errorf("error parsing Avro schema: %w", err)
+	}
+
+	p := &avroParser{named: make(map[string]*avroSchema)}
+	parsed, err := p.parse(v, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Avro schema: %w", err)
+	}
+
+	// Named types can be used before they're defined, so references are resolved once everything is parsed
+	parsed = p.deref(parsed)
+	p.resolve(parsed, make(map[*avroSchema]bool))
+
+	return parsed, nil
+}
+
+func (p *avroParser) parse(v interface{}, namespace string) (*avroSchema, error) {
+	switch t := v.(type) {
+	case string:
+		if avroPrimitives[t] {
+			return &avroSchema{kind: t}, nil
+		}
+		return &avroSchema{kind: "reference", reference: fullName(t, namespace)}, nil
+	case []interface{}:
+		union := &avroSchema{kind: "union"}
+		for _, branch := range t {
+			parsed, err := p.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union.branches = append(union.branches, parsed)
+		}
+		return union, nil
+	case map[string]interface{}:
+		return p.parseObject(t, namespace)
+	default:
+		return nil, fmt.Errorf("unexpected schema %v", v)
+	}
+}
+
+func (p *avroParser) parseObject(o map[string]interface{}, namespace string) (*avroSchema, error) {
+	kind, ok := o["type"].(string)
+	if !ok {
+		// {"type": {...}} and {"type": [...]} wrap another schema
+		if nested, ok := o["type"]; ok {
+			return p.parse(nested, namespace)
+		}
+		return nil, fmt.Errorf("schema %v has no type", o)
+	}
+
+	s := &avroSchema{kind: kind}
+
+	switch kind {
+	case "record", "error", "enum", "fixed":
+		s.kind = strings.Replace(kind, "error", "record", 1)
+		name, _ := o["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("%s has no name", kind)
+		}
+		if ns, ok := o["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		s.name = fullName(name, namespace)
+		if i := strings.LastIndex(s.name, "."); i >= 0 {
+			namespace = s.name[:i]
+		} else {
+			namespace = ""
+		}
+		for _, alias := range toStrings(o["aliases"]) {
+			s.aliases = append(s.aliases, fullName(alias, namespace))
+		}
+		p.named[s.name] = s
+	}
+
+	switch s.kind {
+	case "record":
+		fields, _ := o["fields"].([]interface{})
+		for _, f := range fields {
+			field, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("record %s has an invalid field %v", s.name, f)
+			}
+			name, _ := field["name"].(string)
+			fieldSchema, err := p.parse(field["type"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			_, hasDefault := field["default"]
+			s.fields = append(s.fields, avroField{
+				name:       name,
+				aliases:    toStrings(field["aliases"]),
+				schema:     fieldSchema,
+				hasDefault: hasDefault,
+			})
+		}
+	case "enum":
+		s.symbols = toStrings(o["symbols"])
+		_, s.enumDflt = o["default"]
+	case "fixed":
+		size, _ := o["size"].(float64)
+		s.size = int(size)
+	case "array":
+		items, err := p.parse(o["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	case "map":
+		values, err := p.parse(o["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.values = values
+	default:
+		if !avroPrimitives[s.kind] {
+			// A named type used through the {"type": "name"} form
+			return &avroSchema{kind: "reference", reference: fullName(s.kind, namespace)}, nil
+		}
+	}
+
+	return s, nil
+}
+
+func (p *avroParser) resolve(s *avroSchema, seen map[*avroSchema]bool) {
+	if s == nil || seen[s] {
+		return
+	}
+	seen[s] = true
+
+	for i := range s.fields {
+		s.fields[i].schema = p.deref(s.fields[i].schema)
+		p.resolve(s.fields[i].schema, seen)
+	}
+	for i := range s.branches {
+		s.branches[i] = p.deref(s.branches[i])
+		p.resolve(s.branches[i], seen)
+	}
+	if s.items != nil {
+		s.items = p.deref(s.items)
+		p.resolve(s.items, seen)
+	}
+	if s.values != nil {
+		s.values = p.deref(s.values)
+		p.resolve(s.values, seen)
+	}
+}
+
+// deref replaces a reference to a named type defined in the schema with the type itself. References to types that
+// aren't defined, such as types from schema references, are kept and compared by name.
+func (p *avroParser) deref(s *avroSchema) *avroSchema {
+	if s.kind != "reference" {
+		return s
+	}
+	if named, ok := p.named[s.reference]; ok {
+		return named
+	}
+	// An unqualified name may refer to a type in the null namespace
+	if i := strings.LastIndex(s.reference, "."); i >= 0 {
+		if named, ok := p.named[s.reference[i+1:]]; ok {
+			return named
+		}
+	}
+	return s
+}
+
+func checkAvro(reader string, writer string) ([]string, error) {
+	readerSchema, err := parseAvro(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	writerSchema, err := parseAvro(writer)
+	if err != nil {
+		return nil, err
+	}
+
+	return avroReaderCanRead(readerSchema, writerSchema, "", make(map[[2]*avroSchema]bool)), nil
+}
+
+func avroReaderCanRead(reader *avroSchema, writer *avroSchema, path string, seen map[[2]*avroSchema]bool) []string {
+	pair := [2]*avroSchema{reader, writer}
+	if seen[pair] {
+		return nil
+	}
+	seen[pair] = true
+
+	if writer.kind == "union" {
+		var messages []string
+		for _, branch := range writer.branches {
+			messages = append(messages, avroReaderCanRead(reader, branch, path, seen)...)
+		}
+		return messages
+	}
+
+	if reader.kind == "union" {
+		for _, branch := range reader.branches {
+			if len(avroReaderCanRead(branch, writer, path, copySeen(seen))) == 0 {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: reader union lacks type %s", displayPath(path), writer.describe())}
+	}
+
+	if reader.kind != writer.kind {
+		for _, promotable := range avroPromotions[reader.kind] {
+			if promotable == writer.kind {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: type changed from %s to %s", displayPath(path), writer.describe(), reader.describe())}
+	}
+
+	switch reader.kind {
+	case "reference":
+		if reader.reference != writer.reference {
+			return []string{fmt.Sprintf("%s: type changed from %s to %s", displayPath(path), writer.reference, reader.reference)}
+		}
+	case "record":
+		if !reader.matchesName(writer) {
+			return []string{fmt.Sprintf("%s: record name changed from %s to %s", displayPath(path), writer.name, reader.name)}
+		}
+		var messages []string
+		for _, field := range reader.fields {
+			fieldPath := joinPath(path, field.name)
+			writerField, ok := writer.field(field)
+			if !ok {
+				if !field.hasDefault {
+					messages = append(messages, fmt.Sprintf("%s: field added without a default", fieldPath))
+				}
+				continue
+			}
+			messages = append(messages, avroReaderCanRead(field.schema, writerField.schema, fieldPath, seen)...)
+		}
+		return messages
+	case "enum":
+		if !reader.matchesName(writer) {
+			return []string{fmt.Sprintf("%s: enum name changed from %s to %s", displayPath(path), writer.name, reader.name)}
+		}
+		if reader.enumDflt {
+			return nil
+		}
+		var missing []string
+		for _, symbol := range writer.symbols {
+			if !contains(reader.symbols, symbol) {
+				missing = append(missing, symbol)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return []string{fmt.Sprintf("%s: enum symbols %s removed without a default", displayPath(path), strings.Join(missing, ", "))}
+		}
+	case "fixed":
+		if !reader.matchesName(writer) {
+			return []string{fmt.Sprintf("%s: fixed name changed from %s to %s", displayPath(path), writer.name, reader.name)}
+		}
+		if reader.size != writer.size {
+			return []string{fmt.Sprintf("%s: fixed size changed from %d to %d", displayPath(path), writer.size, reader.size)}
+		}
+	case "array":
+		return avroReaderCanRead(reader.items, writer.items, joinPath(path, "items"), seen)
+	case "map":
+		return avroReaderCanRead(reader.values, writer.values, joinPath(path, "values"), seen)
+	}
+
+	return nil
+}
+
+func (s *avroSchema) describe() string {
+	switch s.kind {
+	case "reference":
+		return s.reference
+	case "record", "enum", "fixed":
+		return fmt.Sprintf("%s %s", s.kind, s.name)
+	}
+	return s.kind
+}
+
+// matchesName compares the unqualified names of named types like the registry does, also accepting reader aliases
+func (s *avroSchema) matchesName(writer *avroSchema) bool {
+	if shortName(s.name) == shortName(writer.name) {
+		return true
+	}
+	for _, alias := range s.aliases {
+		if alias == writer.name || shortName(alias) == shortName(writer.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// field finds the writer field a reader field reads from, by name or by one of the reader field's aliases
+func (s *avroSchema) field(readerField avroField) (avroField, bool) {
+	for _, field := range s.fields {
+		if field.name == readerField.name || contains(readerField.aliases, field.name) {
+			return field, true
+		}
+	}
+	return avroField{}, false
+}
+
+func fullName(name string, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func copySeen(seen map[[2]*avroSchema]bool) map[[2]*avroSchema]bool {
+	copied := make(map[[2]*avroSchema]bool, len(seen))
+	for k, v := range seen {
+		copied[k] = v
+	}
+	return copied
+}
+
+func toStrings(v interface{}) []string {
+	values, _ := v.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Package compatibility implements the schema registry's schema evolution rules offline, so that plans can be checked
+// for compatibility in environments that can't reach the registry.
+package compatibility
+
+import (
+	"fmt"
+
+	"github.com/ashleybill/srclient"
+)
+
+// readerWriterCheck returns the reasons data written with the writer schema can't be read with the reader schema
+type readerWriterCheck func(reader string, writer string) ([]string, error)
+
+// Check returns the incompatibilities between a schema and the previously registered schemas of its subject, ordered
+// oldest first, under the given compatibility level. An empty result means the schema is compatible.
+func Check(level srclient.CompatibilityLevel, schemaType srclient.SchemaType, schema string, previous []string) ([]string, error) {
+	var check readerWriterCheck
+	switch schemaType {
+	case srclient.Avro:
+		check = checkAvro
+	case srclient.Json:
+		check = checkJSON
+	case srclient.Protobuf:
+		check = checkProtobuf
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", schemaType)
+	}
+
+	var against []string
+	var backward, forward bool
+
+	switch level {
+	case srclient.None:
+		return nil, nil
+	case srclient.Backward, srclient.Forward, srclient.Full:
+		if len(previous) > 0 {
+			against = previous[len(previous)-1:]
+		}
+	case srclient.BackwardTransitive, srclient.ForwardTransitive, srclient.FullTransitive:
+		against = previous
+	default:
+		return nil, fmt.Errorf("unsupported compatibility level %q", level)
+	}
+
+	switch level {
+	case srclient.Backward, srclient.BackwardTransitive:
+		backward = true
+	case srclient.Forward, srclient.ForwardTransitive:
+		forward = true
+	default:
+		backward, forward = true, true
+	}
+
+	var incompatibilities []string
+	for i := len(against) - 1; i >= 0; i-- {
+		// previous versions are numbered from the oldest one the check runs against
+		version := len(previous) - len(against) + i + 1
+
+		if backward {
+			messages, err := check(schema, against[i])
+			if err != nil {
+				return nil, err
+			}
+			incompatibilities = append(incompatibilities, prefix(fmt.Sprintf("reading data written with previous version %d", version), messages)...)
+		}
+
+		if forward {
+			messages, err := check(against[i], schema)
+			if err != nil {
+				return nil, err
+			}
+			incompatibilities = append(incompatibilities, prefix(fmt.Sprintf("previous version %d reading data written with the new schema", version), messages)...)
+		}
+	}
+
+	return incompatibilities, nil
+}
+
+func prefix(context string, messages []string) []string {
+	prefixed := make([]string, 0, len(messages))
+	for _, message := range messages {
+		prefixed = append(prefixed, fmt.Sprintf("%s: %s", context, message))
+	}
+	return prefixed
+}
+
+func joinPath(path string, element string) string {
+	if path == "" {
+		return element
+	}
+	return path + "." + element
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "schema root"
+	}
+	return path
+}
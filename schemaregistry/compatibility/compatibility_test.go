@@ -0,0 +1,702 @@
+package compatibility
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ashleybill/srclient"
+)
+
+const avroUser = `{"type":"record","name":"userAdded","namespace":"akc.test","fields":[{"name":"firstName","type":"string"}]}`
+const avroUserWithDefault = `{"type":"record","name":"userAdded","namespace":"akc.test","fields":[{"name":"firstName","type":"string"},{"name":"lastName","type":"string","default":"last"}]}`
+const avroUserWithoutDefault = `{"type":"record","name":"userAdded","namespace":"akc.test","fields":[{"name":"firstName","type":"string"},{"name":"lastName","type":"string"}]}`
+
+type compatibilityCase struct {
+	name       string
+	level      srclient.CompatibilityLevel // defaults to BACKWARD
+	schema     string
+	previous   []string
+	compatible bool
+	contains   string
+}
+
+func runCompatibilityCases(t *testing.T, schemaType srclient.SchemaType, tt []compatibilityCase) {
+	t.Helper()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			level := tc.level
+			if level == "" {
+				level = srclient.Backward
+			}
+
+			incompatibilities, err := Check(level, schemaType, tc.schema, tc.previous)
+			if err != nil {
+				t.Fatalf("expected check not to error, but got: %v", err)
+			}
+
+			if tc.compatible && len(incompatibilities) > 0 {
+				t.Fatalf("expected schema to be compatible, but got: %v", incompatibilities)
+			}
+
+			if !tc.compatible && len(incompatibilities) == 0 {
+				t.Fatal("expected schema to be incompatible")
+			}
+
+			if tc.contains != "" && !strings.Contains(strings.Join(incompatibilities, "\n"), tc.contains) {
+				t.Errorf("expected incompatibilities to contain %q, but got: %v", tc.contains, incompatibilities)
+			}
+		})
+	}
+}
+
+func TestCheckLevels(t *testing.T) {
+	runCompatibilityCases(t, srclient.Avro, []compatibilityCase{
+		{
+			name:       "none allows anything",
+			level:      srclient.None,
+			schema:     `"string"`,
+			previous:   []string{avroUser},
+			compatible: true,
+		},
+		{
+			name:       "no previous versions",
+			level:      srclient.FullTransitive,
+			schema:     avroUser,
+			compatible: true,
+		},
+		{
+			name:       "backward field with default added",
+			level:      srclient.Backward,
+			schema:     avroUserWithDefault,
+			previous:   []string{avroUser},
+			compatible: true,
+		},
+		{
+			name:     "backward field without default added",
+			level:    srclient.Backward,
+			schema:   avroUserWithoutDefault,
+			previous: []string{avroUser},
+			contains: "reading data written with previous version 1: lastName: field added without a default",
+		},
+		{
+			name:       "forward field without default added",
+			level:      srclient.Forward,
+			schema:     avroUserWithoutDefault,
+			previous:   []string{avroUser},
+			compatible: true,
+		},
+		{
+			name:     "forward field without default removed",
+			level:    srclient.Forward,
+			schema:   avroUser,
+			previous: []string{avroUserWithoutDefault},
+			contains: "previous version 1 reading data written with the new schema: lastName: field added without a default",
+		},
+		{
+			name:       "full field with default added",
+			level:      srclient.Full,
+			schema:     avroUserWithDefault,
+			previous:   []string{avroUser},
+			compatible: true,
+		},
+		{
+			name:     "full field without default added",
+			level:    srclient.Full,
+			schema:   avroUserWithoutDefault,
+			previous: []string{avroUser},
+		},
+		{
+			name:       "backward only checks the latest version",
+			level:      srclient.Backward,
+			schema:     avroUserWithoutDefault,
+			previous:   []string{avroUser, avroUserWithoutDefault},
+			compatible: true,
+		},
+		{
+			name:     "backward transitive checks every version",
+			level:    srclient.BackwardTransitive,
+			schema:   avroUserWithoutDefault,
+			previous: []string{avroUser, avroUserWithoutDefault},
+			contains: "previous version 1",
+		},
+		{
+			name:       "forward only checks the latest version",
+			level:      srclient.Forward,
+			schema:     avroUser,
+			previous:   []string{avroUserWithoutDefault, avroUser},
+			compatible: true,
+		},
+		{
+			name:     "forward transitive checks every version",
+			level:    srclient.ForwardTransitive,
+			schema:   avroUser,
+			previous: []string{avroUserWithoutDefault, avroUser},
+			contains: "previous version 1",
+		},
+		{
+			name:     "full transitive checks every version",
+			level:    srclient.FullTransitive,
+			schema:   avroUserWithDefault,
+			previous: []string{`{"type":"record","name":"userAdded","namespace":"akc.test","fields":[{"name":"firstName","type":"int"}]}`, avroUser},
+			contains: "firstName: type changed",
+		},
+	})
+}
+
+func TestCheckErrors(t *testing.T) {
+	if _, err := Check("SIDEWAYS", srclient.Avro, avroUser, []string{avroUser}); err == nil {
+		t.Error("expected unknown compatibility level to error")
+	}
+
+	if _, err := Check(srclient.Backward, "XML", avroUser, []string{avroUser}); err == nil {
+		t.Error("expected unknown schema type to error")
+	}
+
+	if _, err := Check(srclient.Backward, srclient.Avro, "{", []string{avroUser}); err == nil {
+		t.Error("expected unparseable schema to error")
+	}
+
+	if _, err := Check(srclient.Backward, srclient.Protobuf, "message {", []string{"syntax = \"proto3\";"}); err == nil {
+		t.Error("expected unparseable protobuf schema to error")
+	}
+}
+
+func TestCheckAvro(t *testing.T) {
+	record := func(fields string) string {
+		return `{"type":"record","name":"r","namespace":"ns","fields":[` + fields + `]}`
+	}
+
+	runCompatibilityCases(t, srclient.Avro, []compatibilityCase{
+		{
+			name:       "identical",
+			schema:     avroUser,
+			previous:   []string{avroUser},
+			compatible: true,
+		},
+		{
+			name:       "field removed",
+			schema:     record(`{"name":"a","type":"string"}`),
+			previous:   []string{record(`{"name":"a","type":"string"},{"name":"b","type":"string"}`)},
+			compatible: true,
+		},
+		{
+			name:     "field type changed",
+			schema:   record(`{"name":"a","type":"int"}`),
+			previous: []string{record(`{"name":"a","type":"string"}`)},
+			contains: "a: type changed from string to int",
+		},
+		{
+			name:       "int promoted to long",
+			schema:     record(`{"name":"a","type":"long"}`),
+			previous:   []string{record(`{"name":"a","type":"int"}`)},
+			compatible: true,
+		},
+		{
+			name:       "long promoted to double",
+			schema:     record(`{"name":"a","type":"double"}`),
+			previous:   []string{record(`{"name":"a","type":"long"}`)},
+			compatible: true,
+		},
+		{
+			name:     "long demoted to int",
+			schema:   record(`{"name":"a","type":"int"}`),
+			previous: []string{record(`{"name":"a","type":"long"}`)},
+			contains: "type changed from long to int",
+		},
+		{
+			name:       "bytes read as string",
+			schema:     record(`{"name":"a","type":"string"}`),
+			previous:   []string{record(`{"name":"a","type":"bytes"}`)},
+			compatible: true,
+		},
+		{
+			name:       "field renamed with alias",
+			schema:     record(`{"name":"b","type":"string","aliases":["a"]}`),
+			previous:   []string{record(`{"name":"a","type":"string"}`)},
+			compatible: true,
+		},
+		{
+			name:     "field renamed without alias",
+			schema:   record(`{"name":"b","type":"string"}`),
+			previous: []string{record(`{"name":"a","type":"string"}`)},
+			contains: "b: field added without a default",
+		},
+		{
+			name:     "record renamed",
+			schema:   `{"type":"record","name":"other","fields":[]}`,
+			previous: []string{`{"type":"record","name":"r","fields":[]}`},
+			contains: "record name changed from r to other",
+		},
+		{
+			name:       "record renamed with alias",
+			schema:     `{"type":"record","name":"other","aliases":["r"],"fields":[]}`,
+			previous:   []string{`{"type":"record","name":"r","fields":[]}`},
+			compatible: true,
+		},
+		{
+			name:       "namespace changed",
+			schema:     `{"type":"record","name":"r","namespace":"other","fields":[]}`,
+			previous:   []string{`{"type":"record","name":"r","namespace":"ns","fields":[]}`},
+			compatible: true,
+		},
+		{
+			name:       "type added to union",
+			schema:     record(`{"name":"a","type":["null","string","int"]}`),
+			previous:   []string{record(`{"name":"a","type":["null","string"]}`)},
+			compatible: true,
+		},
+		{
+			name:     "type removed from union",
+			schema:   record(`{"name":"a","type":["null","string"]}`),
+			previous: []string{record(`{"name":"a","type":["null","string","int"]}`)},
+			contains: "a: reader union lacks type int",
+		},
+		{
+			name:       "field made nullable",
+			schema:     record(`{"name":"a","type":["null","string"]}`),
+			previous:   []string{record(`{"name":"a","type":"string"}`)},
+			compatible: true,
+		},
+		{
+			name:     "field made required",
+			schema:   record(`{"name":"a","type":"string"}`),
+			previous: []string{record(`{"name":"a","type":["null","string"]}`)},
+			contains: "type changed from null to string",
+		},
+		{
+			name:       "enum symbol added",
+			schema:     record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A","B","C"]}}`),
+			previous:   []string{record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A","B"]}}`)},
+			compatible: true,
+		},
+		{
+			name:     "enum symbol removed",
+			schema:   record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A"]}}`),
+			previous: []string{record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A","B"]}}`)},
+			contains: "enum symbols B removed without a default",
+		},
+		{
+			name:       "enum symbol removed with default",
+			schema:     record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A"],"default":"A"}}`),
+			previous:   []string{record(`{"name":"a","type":{"type":"enum","name":"e","symbols":["A","B"]}}`)},
+			compatible: true,
+		},
+		{
+			name:     "fixed size changed",
+			schema:   record(`{"name":"a","type":{"type":"fixed","name":"f","size":8}}`),
+			previous: []string{record(`{"name":"a","type":{"type":"fixed","name":"f","size":4}}`)},
+			contains: "fixed size changed from 4 to 8",
+		},
+		{
+			name:       "array items promoted",
+			schema:     record(`{"name":"a","type":{"type":"array","items":"long"}}`),
+			previous:   []string{record(`{"name":"a","type":{"type":"array","items":"int"}}`)},
+			compatible: true,
+		},
+		{
+			name:     "map values changed",
+			schema:   record(`{"name":"a","type":{"type":"map","values":"int"}}`),
+			previous: []string{record(`{"name":"a","type":{"type":"map","values":"string"}}`)},
+			contains: "a.values: type changed from string to int",
+		},
+		{
+			name:     "nested record field added without default",
+			schema:   record(`{"name":"a","type":{"type":"record","name":"n","fields":[{"name":"x","type":"int"},{"name":"y","type":"int"}]}}`),
+			previous: []string{record(`{"name":"a","type":{"type":"record","name":"n","fields":[{"name":"x","type":"int"}]}}`)},
+			contains: "a.y: field added without a default",
+		},
+		{
+			name:       "named type reused by name",
+			schema:     record(`{"name":"a","type":{"type":"record","name":"n","fields":[{"name":"x","type":"int"}]}},{"name":"b","type":"n"}`),
+			previous:   []string{record(`{"name":"a","type":{"type":"record","name":"n","fields":[{"name":"x","type":"int"}]}},{"name":"b","type":"n"}`)},
+			compatible: true,
+		},
+		{
+			name:       "recursive record",
+			schema:     `{"type":"record","name":"node","fields":[{"name":"next","type":["null","node"]}]}`,
+			previous:   []string{`{"type":"record","name":"node","fields":[{"name":"next","type":["null","node"]}]}`},
+			compatible: true,
+		},
+		{
+			name:       "referenced type unchanged",
+			schema:     `["akc.test.userAdded","akc.test.userRemoved"]`,
+			previous:   []string{`["akc.test.userAdded"]`},
+			compatible: true,
+		},
+		{
+			name:     "referenced type removed",
+			schema:   `["akc.test.userAdded"]`,
+			previous: []string{`["akc.test.userAdded","akc.test.userRemoved"]`},
+			contains: "reader union lacks type akc.test.userRemoved",
+		},
+		{
+			name:       "logical type on the same primitive",
+			schema:     record(`{"name":"a","type":{"type":"long","logicalType":"timestamp-millis"}}`),
+			previous:   []string{record(`{"name":"a","type":"long"}`)},
+			compatible: true,
+		},
+	})
+}
+
+func TestCheckJSON(t *testing.T) {
+	runCompatibilityCases(t, srclient.Json, []compatibilityCase{
+		{
+			name:       "identical",
+			schema:     `{"type":"object","properties":{"a":{"type":"string"}}}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"type":"string"}}}`},
+			compatible: true,
+		},
+		{
+			name:       "type widened",
+			schema:     `{"type":["string","null"]}`,
+			previous:   []string{`{"type":"string"}`},
+			compatible: true,
+		},
+		{
+			name:     "type narrowed",
+			schema:   `{"type":"string"}`,
+			previous: []string{`{"type":["string","null"]}`},
+			contains: "type null removed",
+		},
+		{
+			name:       "integer widened to number",
+			schema:     `{"type":"number"}`,
+			previous:   []string{`{"type":"integer"}`},
+			compatible: true,
+		},
+		{
+			name:     "number narrowed to integer",
+			schema:   `{"type":"integer"}`,
+			previous: []string{`{"type":"number"}`},
+			contains: "type number removed",
+		},
+		{
+			name:     "type added",
+			schema:   `{"type":"string"}`,
+			previous: []string{`{}`},
+			contains: "type narrowed to string",
+		},
+		{
+			name:     "required property added",
+			schema:   `{"type":"object","properties":{"a":{"type":"string"}},"required":["a"],"additionalProperties":false}`,
+			previous: []string{`{"type":"object","properties":{"a":{"type":"string"}},"additionalProperties":false}`},
+			contains: "a: required property added",
+		},
+		{
+			name:       "required property removed",
+			schema:     `{"type":"object","properties":{"a":{"type":"string"}}}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"type":"string"}},"required":["a"]}`},
+			compatible: true,
+		},
+		{
+			name:       "property added to closed content model",
+			schema:     `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}},"additionalProperties":false}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"type":"string"}},"additionalProperties":false}`},
+			compatible: true,
+		},
+		{
+			name:     "property added to open content model",
+			schema:   `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}}}`,
+			previous: []string{`{"type":"object","properties":{"a":{"type":"string"}}}`},
+			contains: "b: property added to open content model",
+		},
+		{
+			name:       "unconstrained property added to open content model",
+			schema:     `{"type":"object","properties":{"a":{"type":"string"},"b":{}}}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"type":"string"}}}`},
+			compatible: true,
+		},
+		{
+			name:       "property added covered by writer additionalProperties",
+			schema:     `{"type":"object","properties":{"b":{"type":"string"}},"additionalProperties":{"type":"string"}}`,
+			previous:   []string{`{"type":"object","additionalProperties":{"type":"string"}}`},
+			compatible: true,
+		},
+		{
+			name:       "property removed from open content model",
+			schema:     `{"type":"object","properties":{"a":{"type":"string"}}}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}}}`},
+			compatible: true,
+		},
+		{
+			name:     "property removed from closed content model",
+			schema:   `{"type":"object","properties":{"a":{"type":"string"}},"additionalProperties":false}`,
+			previous: []string{`{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}},"additionalProperties":false}`},
+			contains: "b: property removed from closed content model",
+		},
+		{
+			name:     "content model closed",
+			schema:   `{"type":"object","properties":{"a":{"type":"string"}},"additionalProperties":false}`,
+			previous: []string{`{"type":"object","properties":{"a":{"type":"string"}}}`},
+			contains: "additionalProperties changed to false",
+		},
+		{
+			name:     "nested property type changed",
+			schema:   `{"type":"object","properties":{"a":{"type":"object","properties":{"b":{"type":"integer"}}}}}`,
+			previous: []string{`{"type":"object","properties":{"a":{"type":"object","properties":{"b":{"type":"string"}}}}}`},
+			contains: "a.b: type string removed",
+		},
+		{
+			name:       "enum value added",
+			schema:     `{"enum":["A","B","C"]}`,
+			previous:   []string{`{"enum":["A","B"]}`},
+			compatible: true,
+		},
+		{
+			name:     "enum value removed",
+			schema:   `{"enum":["A"]}`,
+			previous: []string{`{"enum":["A","B"]}`},
+			contains: "enum values B removed",
+		},
+		{
+			name:     "max length decreased",
+			schema:   `{"type":"string","maxLength":5}`,
+			previous: []string{`{"type":"string","maxLength":10}`},
+			contains: "maxLength decreased",
+		},
+		{
+			name:       "max length increased",
+			schema:     `{"type":"string","maxLength":20}`,
+			previous:   []string{`{"type":"string","maxLength":10}`},
+			compatible: true,
+		},
+		{
+			name:     "minimum increased",
+			schema:   `{"type":"number","minimum":5}`,
+			previous: []string{`{"type":"number","minimum":1}`},
+			contains: "minimum increased",
+		},
+		{
+			name:       "minimum removed",
+			schema:     `{"type":"number"}`,
+			previous:   []string{`{"type":"number","minimum":1}`},
+			compatible: true,
+		},
+		{
+			name:     "pattern changed",
+			schema:   `{"type":"string","pattern":"^[a-z]+$"}`,
+			previous: []string{`{"type":"string","pattern":"^[a-zA-Z]+$"}`},
+			contains: "pattern changed",
+		},
+		{
+			name:     "array items changed",
+			schema:   `{"type":"array","items":{"type":"integer"}}`,
+			previous: []string{`{"type":"array","items":{"type":"string"}}`},
+			contains: "items: type string removed",
+		},
+		{
+			name:       "oneOf alternative added",
+			schema:     `{"oneOf":[{"type":"string"},{"type":"integer"}]}`,
+			previous:   []string{`{"oneOf":[{"type":"string"}]}`},
+			compatible: true,
+		},
+		{
+			name:     "oneOf alternative removed",
+			schema:   `{"oneOf":[{"type":"string"}]}`,
+			previous: []string{`{"oneOf":[{"type":"string"},{"type":"integer"}]}`},
+			contains: "oneOf alternative 1 is no longer accepted",
+		},
+		{
+			name:       "schema wrapped in oneOf",
+			schema:     `{"oneOf":[{"type":"string"},{"type":"null"}]}`,
+			previous:   []string{`{"type":"string"}`},
+			compatible: true,
+		},
+		{
+			name:       "local definitions",
+			schema:     `{"type":"object","properties":{"a":{"$ref":"#/definitions/a"}},"definitions":{"a":{"type":["string","null"]}}}`,
+			previous:   []string{`{"type":"object","properties":{"a":{"$ref":"#/definitions/a"}},"definitions":{"a":{"type":"string"}}}`},
+			compatible: true,
+		},
+		{
+			name:     "local definition narrowed",
+			schema:   `{"type":"object","properties":{"a":{"$ref":"#/$defs/a"}},"$defs":{"a":{"type":"string"}}}`,
+			previous: []string{`{"type":"object","properties":{"a":{"$ref":"#/$defs/a"}},"$defs":{"a":{"type":["string","null"]}}}`},
+			contains: "a: type null removed",
+		},
+		{
+			name:       "recursive definition",
+			schema:     `{"$ref":"#/definitions/node","definitions":{"node":{"type":"object","properties":{"next":{"$ref":"#/definitions/node"}}}}}`,
+			previous:   []string{`{"$ref":"#/definitions/node","definitions":{"node":{"type":"object","properties":{"next":{"$ref":"#/definitions/node"}}}}}`},
+			compatible: true,
+		},
+		{
+			name:     "external reference changed",
+			schema:   `{"type":"object","properties":{"a":{"$ref":"other.json"}},"additionalProperties":false}`,
+			previous: []string{`{"type":"object","properties":{"a":{"$ref":"user.json"}},"additionalProperties":false}`},
+			contains: `reference changed from "user.json" to "other.json"`,
+		},
+		{
+			name:     "false schema",
+			schema:   `false`,
+			previous: []string{`{"type":"string"}`},
+			contains: "schema changed to reject every value",
+		},
+		{
+			name:       "true schema",
+			schema:     `true`,
+			previous:   []string{`{"type":"string"}`},
+			compatible: true,
+		},
+	})
+}
+
+func TestCheckProtobuf(t *testing.T) {
+	proto := func(body string) string {
+		return "syntax = \"proto3\";\npackage com.fetchrewards.test;\n" + body
+	}
+
+	runCompatibilityCases(t, srclient.Protobuf, []compatibilityCase{
+		{
+			name:       "identical",
+			schema:     proto(`message A { string a = 1; }`),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:       "whitespace and field rename",
+			schema:     proto("message A {\n  string renamed = 1;\n}\n"),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:       "field added",
+			schema:     proto(`message A { string a = 1; int32 b = 2; }`),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:       "field removed",
+			schema:     proto(`message A { string a = 1; }`),
+			previous:   []string{proto(`message A { string a = 1; int32 b = 2; }`)},
+			compatible: true,
+		},
+		{
+			name:     "scalar type changed",
+			schema:   proto(`message A { int32 a = 1; }`),
+			previous: []string{proto(`message A { string a = 1; }`)},
+			contains: "A.a: field 1 changed from string to int32",
+		},
+		{
+			name:       "int32 widened to int64",
+			schema:     proto(`message A { int64 a = 1; }`),
+			previous:   []string{proto(`message A { int32 a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:       "string read as bytes",
+			schema:     proto(`message A { bytes a = 1; }`),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:     "sint32 changed to int32",
+			schema:   proto(`message A { int32 a = 1; }`),
+			previous: []string{proto(`message A { sint32 a = 1; }`)},
+			contains: "changed from sint32 to int32",
+		},
+		{
+			name:     "scalar changed to message",
+			schema:   proto(`message B {} message A { B a = 1; }`),
+			previous: []string{proto(`message B {} message A { string a = 1; }`)},
+			contains: "changed from scalar to named",
+		},
+		{
+			name:     "message type changed",
+			schema:   proto(`message B {} message C {} message A { C a = 1; }`),
+			previous: []string{proto(`message B {} message C {} message A { B a = 1; }`)},
+			contains: "changed from type B to C",
+		},
+		{
+			name:       "qualified type name",
+			schema:     proto(`message B {} message A { com.fetchrewards.test.B a = 1; }`),
+			previous:   []string{proto(`message B {} message A { B a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:     "message removed",
+			schema:   proto(`message A { string a = 1; }`),
+			previous: []string{proto(`message A { string a = 1; } message B { string b = 1; }`)},
+			contains: "B: message removed",
+		},
+		{
+			name:       "message added",
+			schema:     proto(`message A { string a = 1; } message B { string b = 1; }`),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:     "nested message field changed",
+			schema:   proto(`message A { message N { int32 n = 1; } N a = 1; }`),
+			previous: []string{proto(`message A { message N { string n = 1; } N a = 1; }`)},
+			contains: "A.N.n: field 1 changed from string to int32",
+		},
+		{
+			name:     "package changed",
+			schema:   "syntax = \"proto3\";\npackage com.fetchrewards.other;\nmessage A { string a = 1; }",
+			previous: []string{proto(`message A { string a = 1; }`)},
+			contains: "package changed",
+		},
+		{
+			name:     "syntax changed",
+			schema:   "syntax = \"proto2\";\npackage com.fetchrewards.test;\nmessage A { optional string a = 1; }",
+			previous: []string{proto(`message A { string a = 1; }`)},
+			contains: "syntax changed from proto3 to proto2",
+		},
+		{
+			name:     "field made repeated",
+			schema:   proto(`message A { repeated string a = 1; }`),
+			previous: []string{proto(`message A { string a = 1; }`)},
+			contains: "changed between repeated and singular",
+		},
+		{
+			name:       "field moved into new oneof",
+			schema:     proto(`message A { oneof choice { string a = 1; } }`),
+			previous:   []string{proto(`message A { string a = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:     "multiple fields moved into oneof",
+			schema:   proto(`message A { oneof choice { string a = 1; string b = 2; } }`),
+			previous: []string{proto(`message A { string a = 1; string b = 2; }`)},
+			contains: "2 existing fields moved into oneof choice",
+		},
+		{
+			name:     "oneof field removed",
+			schema:   proto(`message A { oneof choice { string a = 1; } }`),
+			previous: []string{proto(`message A { oneof choice { string a = 1; int32 b = 2; } }`)},
+			contains: "A.b: oneof field 2 removed",
+		},
+		{
+			name:       "proto3 optional field removed",
+			schema:     proto(`message A { string a = 1; }`),
+			previous:   []string{proto(`message A { string a = 1; optional int32 b = 2; }`)},
+			compatible: true,
+		},
+		{
+			name:     "proto2 required field added",
+			schema:   "syntax = \"proto2\";\nmessage A { optional string a = 1; required int32 b = 2; }",
+			previous: []string{"syntax = \"proto2\";\nmessage A { optional string a = 1; }"},
+			contains: "A.b: required field 2 added",
+		},
+		{
+			name:     "proto2 required field removed",
+			schema:   "syntax = \"proto2\";\nmessage A { optional string a = 1; }",
+			previous: []string{"syntax = \"proto2\";\nmessage A { optional string a = 1; required int32 b = 2; }"},
+			contains: "A.b: required field 2 removed",
+		},
+		{
+			name:       "map field unchanged",
+			schema:     proto(`message A { map<string, int32> m = 1; }`),
+			previous:   []string{proto(`message A { map<string, int32> m = 1; }`)},
+			compatible: true,
+		},
+		{
+			name:       "imported type unchanged",
+			schema:     proto("import \"google/protobuf/timestamp.proto\";\nmessage A { google.protobuf.Timestamp at = 1; string b = 2; }"),
+			previous:   []string{proto("import \"google/protobuf/timestamp.proto\";\nmessage A { google.protobuf.Timestamp at = 1; }")},
+			compatible: true,
+		},
+	})
+}
@@ -0,0 +1,389 @@
+package compatibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// jsonSchema wraps a parsed JSON schema document, so that local $refs can be resolved against its root
+type jsonSchema struct {
+	root interface{}
+}
+
+func parseJSONSchema(schema string) (*jsonSchema, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schema), &v); err != nil {
+		return nil, fmt.Errorf("error parsing JSON schema: %w", err)
+	}
+	return &jsonSchema{root: v}, nil
+}
+
+func checkJSON(reader string, writer string) ([]string, error) {
+	readerSchema, err := parseJSONSchema(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	writerSchema, err := parseJSONSchema(writer)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &jsonChecker{reader: readerSchema, writer: writerSchema, seen: make(map[string]bool)}
+	return c.canRead(readerSchema.root, writerSchema.root, ""), nil
+}
+
+type jsonChecker struct {
+	reader *jsonSchema
+	writer *jsonSchema
+	seen   map[string]bool
+}
+
+// canRead reports the ways a value valid against the writer schema may be rejected by the reader schema. This
+// follows the registry's rules, in which an open content model (additionalProperties not false) may contain any
+// property, so adding a constrained property to one is incompatible.
+func (c *jsonChecker) canRead(reader interface{}, writer interface{}, path string) []string {
+	reader, readerRef := c.reader.deref(reader)
+	writer, writerRef := c.writer.deref(writer)
+
+	if readerRef != "" || writerRef != "" {
+		key := readerRef + "|" + writerRef
+		if c.seen[key] {
+			return nil
+		}
+		c.seen[key] = true
+	}
+
+	if accepts, ok := reader.(bool); ok {
+		if accepts {
+			return nil
+		}
+		if writerAccepts, ok := writer.(bool); ok && !writerAccepts {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: schema changed to reject every value", displayPath(path))}
+	}
+
+	readerObject, _ := reader.(map[string]interface{})
+	if len(readerObject) == 0 {
+		return nil
+	}
+
+	writerObject, ok := writer.(map[string]interface{})
+	if !ok {
+		if writerAccepts, _ := writer.(bool); !writerAccepts {
+			return nil
+		}
+		writerObject = map[string]interface{}{}
+	}
+
+	var messages []string
+
+	if unresolved, ok := readerObject["$ref"].(string); ok {
+		if other, _ := writerObject["$ref"].(string); other != unresolved {
+			messages = append(messages, fmt.Sprintf("%s: reference changed from %q to %q", displayPath(path), other, unresolved))
+		}
+		return messages
+	}
+
+	messages = append(messages, c.checkTypes(readerObject, writerObject, path)...)
+	messages = append(messages, checkEnum(readerObject, writerObject, path)...)
+	messages = append(messages, checkBounds(readerObject, writerObject, path)...)
+
+	for _, keyword := range []string{"pattern", "format", "const", "multipleOf"} {
+		readerValue, readerHas := readerObject[keyword]
+		writerValue, writerHas := writerObject[keyword]
+		if readerHas && (!writerHas || !reflect.DeepEqual(readerValue, writerValue)) {
+			messages = append(messages, fmt.Sprintf("%s: %s changed", displayPath(path), keyword))
+		}
+	}
+
+	messages = append(messages, c.checkObject(readerObject, writerObject, path)...)
+	messages = append(messages, c.checkArray(readerObject, writerObject, path)...)
+	messages = append(messages, c.checkCombined(readerObject, writerObject, path)...)
+
+	return messages
+}
+
+func (c *jsonChecker) checkTypes(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	readerTypes := jsonTypes(reader)
+	if readerTypes == nil {
+		return nil
+	}
+
+	writerTypes := jsonTypes(writer)
+	if writerTypes == nil {
+		return []string{fmt.Sprintf("%s: type narrowed to %s", displayPath(path), strings.Join(readerTypes, ", "))}
+	}
+
+	var narrowed []string
+	for _, writerType := range writerTypes {
+		if contains(readerTypes, writerType) || (writerType == "integer" && contains(readerTypes, "number")) {
+			continue
+		}
+		narrowed = append(narrowed, writerType)
+	}
+
+	if len(narrowed) > 0 {
+		return []string{fmt.Sprintf("%s: type %s removed", displayPath(path), strings.Join(narrowed, ", "))}
+	}
+
+	return nil
+}
+
+func checkEnum(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	readerEnum, ok := reader["enum"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	writerEnum, ok := writer["enum"].([]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: enum added", displayPath(path))}
+	}
+
+	var removed []string
+	for _, value := range writerEnum {
+		found := false
+		for _, readerValue := range readerEnum {
+			if reflect.DeepEqual(value, readerValue) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			removed = append(removed, fmt.Sprintf("%v", value))
+		}
+	}
+
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		return []string{fmt.Sprintf("%s: enum values %s removed", displayPath(path), strings.Join(removed, ", "))}
+	}
+
+	return nil
+}
+
+// checkBounds reports minimums that were raised and maximums that were lowered
+func checkBounds(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	var messages []string
+
+	for _, keyword := range []string{"minLength", "minItems", "minProperties", "minimum", "exclusiveMinimum"} {
+		readerValue, readerHas := reader[keyword].(float64)
+		writerValue, writerHas := writer[keyword].(float64)
+		if readerHas && (!writerHas || readerValue > writerValue) {
+			messages = append(messages, fmt.Sprintf("%s: %s increased", displayPath(path), keyword))
+		}
+	}
+
+	for _, keyword := range []string{"maxLength", "maxItems", "maxProperties", "maximum", "exclusiveMaximum"} {
+		readerValue, readerHas := reader[keyword].(float64)
+		writerValue, writerHas := writer[keyword].(float64)
+		if readerHas && (!writerHas || readerValue < writerValue) {
+			messages = append(messages, fmt.Sprintf("%s: %s decreased", displayPath(path), keyword))
+		}
+	}
+
+	return messages
+}
+
+func (c *jsonChecker) checkObject(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	var messages []string
+
+	writerRequired := toStrings(writer["required"])
+	for _, required := range toStrings(reader["required"]) {
+		if !contains(writerRequired, required) {
+			messages = append(messages, fmt.Sprintf("%s: required property added", joinPath(path, required)))
+		}
+	}
+
+	readerProperties, _ := reader["properties"].(map[string]interface{})
+	writerProperties, _ := writer["properties"].(map[string]interface{})
+	readerAdditional, readerHasAdditional := reader["additionalProperties"]
+	writerAdditional, writerHasAdditional := writer["additionalProperties"]
+
+	for _, name := range sortedKeys(readerProperties) {
+		propertyPath := joinPath(path, name)
+		if writerProperty, ok := writerProperties[name]; ok {
+			messages = append(messages, c.canRead(readerProperties[name], writerProperty, propertyPath)...)
+			continue
+		}
+
+		if writerHasAdditional {
+			// A closed writer never produced the property, otherwise it was validated by additionalProperties
+			if closed, ok := writerAdditional.(bool); ok && !closed {
+				continue
+			}
+			messages = append(messages, c.canRead(readerProperties[name], writerAdditional, propertyPath)...)
+			continue
+		}
+
+		if !isEmptySchema(readerProperties[name]) {
+			messages = append(messages, fmt.Sprintf("%s: property added to open content model", propertyPath))
+		}
+	}
+
+	for _, name := range sortedKeys(writerProperties) {
+		if _, ok := readerProperties[name]; ok || !readerHasAdditional {
+			continue
+		}
+		propertyPath := joinPath(path, name)
+		if closed, ok := readerAdditional.(bool); ok && !closed {
+			messages = append(messages, fmt.Sprintf("%s: property removed from closed content model", propertyPath))
+			continue
+		}
+		messages = append(messages, c.canRead(readerAdditional, writerProperties[name], propertyPath)...)
+	}
+
+	if readerHasAdditional {
+		if closed, ok := readerAdditional.(bool); ok && !closed {
+			if writerClosed, ok := writerAdditional.(bool); !writerHasAdditional || !ok || writerClosed {
+				messages = append(messages, fmt.Sprintf("%s: additionalProperties changed to false", displayPath(path)))
+			}
+		} else if writerHasAdditional {
+			messages = append(messages, c.canRead(readerAdditional, writerAdditional, joinPath(path, "additionalProperties"))...)
+		} else if !isEmptySchema(readerAdditional) {
+			messages = append(messages, fmt.Sprintf("%s: additionalProperties narrowed", displayPath(path)))
+		}
+	}
+
+	return messages
+}
+
+func (c *jsonChecker) checkArray(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	readerItems, ok := reader["items"]
+	if !ok {
+		return nil
+	}
+
+	writerItems, ok := writer["items"]
+	if !ok {
+		writerItems = true
+	}
+
+	readerTuple, readerIsTuple := readerItems.([]interface{})
+	writerTuple, writerIsTuple := writerItems.([]interface{})
+	if readerIsTuple != writerIsTuple {
+		return []string{fmt.Sprintf("%s: items changed between list and tuple validation", displayPath(path))}
+	}
+
+	if !readerIsTuple {
+		return c.canRead(readerItems, writerItems, joinPath(path, "items"))
+	}
+
+	var messages []string
+	for i, item := range readerTuple {
+		itemPath := joinPath(path, fmt.Sprintf("items[%d]", i))
+		if i < len(writerTuple) {
+			messages = append(messages, c.canRead(item, writerTuple[i], itemPath)...)
+		} else if !isEmptySchema(item) {
+			messages = append(messages, fmt.Sprintf("%s: tuple item added", itemPath))
+		}
+	}
+	return messages
+}
+
+// checkCombined requires every alternative of the writer's anyOf/oneOf to be readable by one of the reader's
+func (c *jsonChecker) checkCombined(reader map[string]interface{}, writer map[string]interface{}, path string) []string {
+	var messages []string
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		readerAlternatives, ok := reader[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+
+		writerAlternatives, ok := writer[keyword].([]interface{})
+		if !ok {
+			writerAlternatives = []interface{}{writer}
+		}
+
+		for i, writerAlternative := range writerAlternatives {
+			readable := false
+			for _, readerAlternative := range readerAlternatives {
+				checker := &jsonChecker{reader: c.reader, writer: c.writer, seen: make(map[string]bool)}
+				if len(checker.canRead(readerAlternative, writerAlternative, path)) == 0 {
+					readable = true
+					break
+				}
+			}
+			if !readable {
+				messages = append(messages, fmt.Sprintf("%s: %s alternative %d is no longer accepted", displayPath(path), keyword, i))
+			}
+		}
+	}
+
+	readerAll, _ := reader["allOf"].([]interface{})
+	writerAll, _ := writer["allOf"].([]interface{})
+	if len(readerAll) > 0 && !reflect.DeepEqual(readerAll, writerAll) {
+		for i, readerSchema := range readerAll {
+			if i < len(writerAll) {
+				messages = append(messages, c.canRead(readerSchema, writerAll[i], joinPath(path, fmt.Sprintf("allOf[%d]", i)))...)
+			} else {
+				messages = append(messages, fmt.Sprintf("%s: allOf schema %d added", displayPath(path), i))
+			}
+		}
+	}
+
+	return messages
+}
+
+// deref follows local $refs ("#/definitions/name", "#/$defs/name") and returns the pointer it followed. External refs
+// point at schema references and are left in place.
+func (s *jsonSchema) deref(v interface{}) (interface{}, string) {
+	var followed string
+	for i := 0; i < 32; i++ {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return v, followed
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return v, followed
+		}
+
+		target := s.root
+		for _, token := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+			if token == "" {
+				continue
+			}
+			container, ok := target.(map[string]interface{})
+			if !ok {
+				return v, followed
+			}
+			target = container[strings.NewReplacer("~1", "/", "~0", "~").Replace(token)]
+		}
+		v, followed = target, ref
+	}
+	return v, followed
+}
+
+func jsonTypes(schema map[string]interface{}) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		return toStrings(t)
+	}
+	return nil
+}
+
+func isEmptySchema(v interface{}) bool {
+	if accepts, ok := v.(bool); ok {
+		return accepts
+	}
+	object, ok := v.(map[string]interface{})
+	return ok && len(object) == 0
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
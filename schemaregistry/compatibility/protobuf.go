@@ -0,0 +1,243 @@
+package compatibility
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protoWireCompatible groups the scalar types that share a wire encoding, and so can replace each other
+var protoWireCompatible = [][]descriptorpb.FieldDescriptorProto_Type{
+	{
+		descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	},
+	{
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+	},
+	{
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	},
+	{
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	},
+	{
+		descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	},
+}
+
+func parseProtobuf(schema string) (*descriptorpb.FileDescriptorProto, error) {
+	errHandler := reporter.NewHandler(nil)
+	node, err := parser.Parse("schema.proto", strings.NewReader(schema), errHandler)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing protobuf schema: %w", err)
+	}
+
+	res, err := parser.ResultFromAST(node, true, errHandler)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing protobuf schema: %w", err)
+	}
+
+	return res.FileDescriptorProto(), nil
+}
+
+// checkProtobuf applies the registry's protobuf rules, which only look at what changes the wire format: removed
+// messages, fields whose number now maps to a different type, and changes to oneofs and required fields
+func checkProtobuf(reader string, writer string) ([]string, error) {
+	readerFile, err := parseProtobuf(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	writerFile, err := parseProtobuf(writer)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+
+	if readerFile.GetPackage() != writerFile.GetPackage() {
+		messages = append(messages, fmt.Sprintf("package changed from %q to %q", writerFile.GetPackage(), readerFile.GetPackage()))
+	}
+
+	if protoSyntax(readerFile) != protoSyntax(writerFile) {
+		messages = append(messages, fmt.Sprintf("syntax changed from %s to %s", protoSyntax(writerFile), protoSyntax(readerFile)))
+	}
+
+	readerMessages := protoMessages(readerFile.GetMessageType(), "")
+	writerMessages := protoMessages(writerFile.GetMessageType(), "")
+
+	for _, name := range sortedMessageNames(writerMessages) {
+		readerMessage, ok := readerMessages[name]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("%s: message removed", name))
+			continue
+		}
+		messages = append(messages, checkProtoMessage(readerMessage, writerMessages[name], name, readerFile.GetPackage(), writerFile.GetPackage())...)
+	}
+
+	return messages, nil
+}
+
+func checkProtoMessage(reader *descriptorpb.DescriptorProto, writer *descriptorpb.DescriptorProto, path string, readerPackage string, writerPackage string) []string {
+	var messages []string
+
+	readerFields := make(map[int32]*descriptorpb.FieldDescriptorProto)
+	for _, field := range reader.GetField() {
+		readerFields[field.GetNumber()] = field
+	}
+
+	writerFields := make(map[int32]*descriptorpb.FieldDescriptorProto)
+	for _, field := range writer.GetField() {
+		writerFields[field.GetNumber()] = field
+	}
+
+	for _, writerField := range writer.GetField() {
+		fieldPath := joinPath(path, writerField.GetName())
+		readerField, ok := readerFields[writerField.GetNumber()]
+		if !ok {
+			if writerField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED {
+				messages = append(messages, fmt.Sprintf("%s: required field %d removed", fieldPath, writerField.GetNumber()))
+			}
+			if writerField.OneofIndex != nil && !writerField.GetProto3Optional() {
+				messages = append(messages, fmt.Sprintf("%s: oneof field %d removed", fieldPath, writerField.GetNumber()))
+			}
+			continue
+		}
+
+		messages = append(messages, checkProtoField(readerField, writerField, fieldPath, readerPackage, writerPackage)...)
+	}
+
+	for _, readerField := range reader.GetField() {
+		if _, ok := writerFields[readerField.GetNumber()]; !ok && readerField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED {
+			messages = append(messages, fmt.Sprintf("%s: required field %d added", joinPath(path, readerField.GetName()), readerField.GetNumber()))
+		}
+	}
+
+	// Moving several existing fields into the same oneof means data with more than one of them set can't be read
+	movedToOneof := make(map[int32]int)
+	for _, readerField := range reader.GetField() {
+		if readerField.OneofIndex == nil || readerField.GetProto3Optional() {
+			continue
+		}
+		if writerField, ok := writerFields[readerField.GetNumber()]; ok && writerField.OneofIndex == nil {
+			movedToOneof[readerField.GetOneofIndex()]++
+		}
+	}
+	for index, oneof := range reader.GetOneofDecl() {
+		if moved := movedToOneof[int32(index)]; moved > 1 {
+			messages = append(messages, fmt.Sprintf("%s: %d existing fields moved into oneof %s", path, moved, oneof.GetName()))
+		}
+	}
+
+	return messages
+}
+
+func checkProtoField(reader *descriptorpb.FieldDescriptorProto, writer *descriptorpb.FieldDescriptorProto, path string, readerPackage string, writerPackage string) []string {
+	readerKind, writerKind := protoFieldKind(reader), protoFieldKind(writer)
+	if readerKind != writerKind {
+		return []string{fmt.Sprintf("%s: field %d changed from %s to %s", path, writer.GetNumber(), writerKind, readerKind)}
+	}
+
+	if (reader.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) != (writer.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) {
+		return []string{fmt.Sprintf("%s: field %d changed between repeated and singular", path, writer.GetNumber())}
+	}
+
+	if readerKind == "scalar" {
+		if reader.GetType() != writer.GetType() && !protoScalarsCompatible(reader.GetType(), writer.GetType()) {
+			return []string{fmt.Sprintf("%s: field %d changed from %s to %s", path, writer.GetNumber(), protoTypeName(writer.GetType()), protoTypeName(reader.GetType()))}
+		}
+		return nil
+	}
+
+	readerType := qualifiedTypeName(reader.GetTypeName(), readerPackage)
+	writerType := qualifiedTypeName(writer.GetTypeName(), writerPackage)
+	if readerType != writerType {
+		return []string{fmt.Sprintf("%s: field %d changed from type %s to %s", path, writer.GetNumber(), writerType, readerType)}
+	}
+
+	return nil
+}
+
+// protoFieldKind classifies a field as a scalar or a named type. Messages and enums can't be told apart without
+// linking the schema against its references, so both are compared by type name.
+func protoFieldKind(field *descriptorpb.FieldDescriptorProto) string {
+	switch {
+	case field.Type == nil,
+		field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+		field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		return "named"
+	}
+	return "scalar"
+}
+
+func protoScalarsCompatible(a descriptorpb.FieldDescriptorProto_Type, b descriptorpb.FieldDescriptorProto_Type) bool {
+	for _, group := range protoWireCompatible {
+		var hasA, hasB bool
+		for _, t := range group {
+			hasA = hasA || t == a
+			hasB = hasB || t == b
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+	return false
+}
+
+func protoTypeName(t descriptorpb.FieldDescriptorProto_Type) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "TYPE_"))
+}
+
+// qualifiedTypeName strips the package from a type name, so that relative and fully qualified names compare equal
+func qualifiedTypeName(typeName string, pkg string) string {
+	typeName = strings.TrimPrefix(typeName, ".")
+	if pkg != "" {
+		typeName = strings.TrimPrefix(typeName, pkg+".")
+	}
+	return typeName
+}
+
+func protoSyntax(file *descriptorpb.FileDescriptorProto) string {
+	if file.GetSyntax() == "" {
+		return "proto2"
+	}
+	return file.GetSyntax()
+}
+
+func protoMessages(descriptors []*descriptorpb.DescriptorProto, scope string) map[string]*descriptorpb.DescriptorProto {
+	messages := make(map[string]*descriptorpb.DescriptorProto)
+	for _, descriptor := range descriptors {
+		// map fields are represented by synthetic entry messages, which are compared through their fields instead
+		if descriptor.GetOptions().GetMapEntry() {
+			continue
+		}
+		name := joinPath(scope, descriptor.GetName())
+		messages[name] = descriptor
+		for nestedName, nested := range protoMessages(descriptor.GetNestedType(), name) {
+			messages[nestedName] = nested
+		}
+	}
+	return messages
+}
+
+func sortedMessageNames(messages map[string]*descriptorpb.DescriptorProto) []string {
+	names := make([]string, 0, len(messages))
+	for name := range messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,552 @@
+package schemaregistry
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The rule kinds: conditions check data and fail, transforms change it
+const (
+	ruleKindCondition = "CONDITION"
+	ruleKindTransform = "TRANSFORM"
+)
+
+// The modes of domain rules, which run when data is written or read, and of migration rules, which run when data
+// written with one version is read with another
+var (
+	domainRuleModes    = []string{"WRITE", "READ", "WRITEREAD"}
+	migrationRuleModes = []string{"UPGRADE", "DOWNGRADE", "UPDOWN"}
+)
+
+// ruleActions are what on_success and on_failure can do. Rules that run both ways take a pair, such as "NONE,ERROR".
+var ruleActions = []string{"NONE", "ERROR", "DLQ"}
+
+// registryPropertyPrefix marks metadata properties the registry manages itself, such as confluent:version
+const registryPropertyPrefix = "confluent:"
+
+// metadataSchema is the data contract metadata block
+func metadataSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Tags of the fields at a path, such as PII",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The path of the fields, such as **.ssn",
+							},
+							"tags": {
+								Type:        schema.TypeSet,
+								Required:    true,
+								MinItems:    1,
+								Description: "The tags of the fields",
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"properties": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "Free-form properties, such as the owner of the schema",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"sensitive": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The properties whose values are sensitive",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// ruleSetSchema is the data contract rule set block
+func ruleSetSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"domain_rules": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Rules that run, in order, when data is written or read",
+					Elem:        ruleResource(domainRuleModes),
+				},
+				"migration_rules": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Rules that run, in order, when data written with another version is read",
+					Elem:        ruleResource(migrationRuleModes),
+				},
+			},
+		},
+	}
+}
+
+func ruleResource(modes []string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the rule, unique within the rule set",
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The kind of rule: CONDITION or TRANSFORM",
+				ValidateFunc: validation.StringInSlice([]string{ruleKindCondition, ruleKindTransform}, false),
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "When the rule runs: " + strings.Join(modes, ", "),
+				ValidateFunc: validation.StringInSlice(modes, false),
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The rule executor, such as CEL, CEL_FIELD, JSONATA or ENCRYPT",
+			},
+			"expr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The expression the rule executor runs",
+			},
+			"params": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Parameters of the rule executor",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"on_success": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "What to do when the rule succeeds: NONE, ERROR or DLQ, or a pair for rules that run both ways",
+			},
+			"on_failure": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "What to do when the rule fails: NONE, ERROR or DLQ, or a pair for rules that run both ways",
+			},
+			"disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the rule is skipped",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The tags of the fields the rule applies to",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// computedOnly copies a block schema for a data source, where every attribute is read from the registry
+func computedOnly(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:        s.Type,
+		Computed:    true,
+		Description: s.Description,
+		Sensitive:   s.Sensitive,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		attributes := make(map[string]*schema.Schema, len(elem.Schema))
+		for name, attribute := range elem.Schema {
+			attributes[name] = computedOnly(attribute)
+		}
+		computed.Elem = &schema.Resource{Schema: attributes}
+	case *schema.Schema:
+		computed.Elem = &schema.Schema{Type: elem.Type}
+	}
+
+	return computed
+}
+
+// ToRegistryMetadata converts a metadata block, nil when there is none
+func ToRegistryMetadata(metadata []interface{}) *Metadata {
+	if len(metadata) == 0 || metadata[0] == nil {
+		return nil
+	}
+	m := metadata[0].(map[string]interface{})
+
+	result := &Metadata{}
+	for _, tag := range m["tags"].(*schema.Set).List() {
+		t := tag.(map[string]interface{})
+		if result.Tags == nil {
+			result.Tags = make(map[string][]string)
+		}
+		result.Tags[t["path"].(string)] = sortedStrings(t["tags"].(*schema.Set).List())
+	}
+	for key, value := range m["properties"].(map[string]interface{}) {
+		if result.Properties == nil {
+			result.Properties = make(map[string]string)
+		}
+		result.Properties[key] = value.(string)
+	}
+	if sensitive := m["sensitive"].(*schema.Set).List(); len(sensitive) > 0 {
+		result.Sensitive = sortedStrings(sensitive)
+	}
+
+	return result
+}
+
+// FromRegistryMetadata converts metadata to a metadata block, leaving out the properties the registry manages
+func FromRegistryMetadata(metadata *Metadata) []interface{} {
+	metadata = userMetadata(metadata)
+	if metadata == nil {
+		return make([]interface{}, 0)
+	}
+
+	tags := make([]interface{}, 0, len(metadata.Tags))
+	for path, pathTags := range metadata.Tags {
+		tags = append(tags, map[string]interface{}{
+			"path": path,
+			"tags": stringsToInterfaces(pathTags),
+		})
+	}
+
+	properties := make(map[string]interface{}, len(metadata.Properties))
+	for key, value := range metadata.Properties {
+		properties[key] = value
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"tags":       tags,
+			"properties": properties,
+			"sensitive":  stringsToInterfaces(metadata.Sensitive),
+		},
+	}
+}
+
+// metadataEqual reports whether two schemas have the same metadata, ignoring the properties the registry manages and
+// the order of tags and sensitive properties
+func metadataEqual(m1 *Metadata, m2 *Metadata) bool {
+	return reflect.DeepEqual(userMetadata(m1), userMetadata(m2))
+}
+
+// withoutInheritedMetadata leaves out of metadata the tags, properties and sensitive properties that inherited
+// metadata adds to it, unless configured sets them as well
+func withoutInheritedMetadata(metadata *Metadata, configured *Metadata, inherited ...*Metadata) *Metadata {
+	metadata = userMetadata(metadata)
+	if metadata == nil {
+		return nil
+	}
+	configured = userMetadata(configured)
+	if configured == nil {
+		configured = &Metadata{}
+	}
+
+	inheritedTags := make(map[string]map[string]bool)
+	inheritedProperties := make(map[string]string)
+	inheritedSensitive := make(map[string]bool)
+	for _, m := range inherited {
+		if m = userMetadata(m); m == nil {
+			continue
+		}
+		for path, tags := range m.Tags {
+			if inheritedTags[path] == nil {
+				inheritedTags[path] = make(map[string]bool)
+			}
+			for _, tag := range tags {
+				inheritedTags[path][tag] = true
+			}
+		}
+		for key, value := range m.Properties {
+			inheritedProperties[key] = value
+		}
+		for _, name := range m.Sensitive {
+			inheritedSensitive[name] = true
+		}
+	}
+
+	result := &Metadata{}
+	for path, tags := range metadata.Tags {
+		own := make(map[string]bool)
+		for _, tag := range configured.Tags[path] {
+			own[tag] = true
+		}
+
+		for _, tag := range tags {
+			if inheritedTags[path][tag] && !own[tag] {
+				continue
+			}
+			if result.Tags == nil {
+				result.Tags = make(map[string][]string)
+			}
+			result.Tags[path] = append(result.Tags[path], tag)
+		}
+	}
+	for key, value := range metadata.Properties {
+		if _, ok := configured.Properties[key]; !ok {
+			if inheritedValue, ok := inheritedProperties[key]; ok && inheritedValue == value {
+				continue
+			}
+		}
+		if result.Properties == nil {
+			result.Properties = make(map[string]string)
+		}
+		result.Properties[key] = value
+	}
+	own := make(map[string]bool)
+	for _, name := range configured.Sensitive {
+		own[name] = true
+	}
+	for _, name := range metadata.Sensitive {
+		if inheritedSensitive[name] && !own[name] {
+			continue
+		}
+		result.Sensitive = append(result.Sensitive, name)
+	}
+
+	return userMetadata(result)
+}
+
+// userMetadata is metadata without the properties the registry manages, sorted, or nil when nothing is left
+func userMetadata(metadata *Metadata) *Metadata {
+	if metadata == nil {
+		return nil
+	}
+
+	result := &Metadata{}
+	for path, tags := range metadata.Tags {
+		if result.Tags == nil {
+			result.Tags = make(map[string][]string)
+		}
+		result.Tags[path] = sortedStrings(stringsToInterfaces(tags))
+	}
+	for key, value := range metadata.Properties {
+		if strings.HasPrefix(key, registryPropertyPrefix) {
+			continue
+		}
+		if result.Properties == nil {
+			result.Properties = make(map[string]string)
+		}
+		result.Properties[key] = value
+	}
+	if len(metadata.Sensitive) > 0 {
+		result.Sensitive = sortedStrings(stringsToInterfaces(metadata.Sensitive))
+	}
+
+	if result.Tags == nil && result.Properties == nil && result.Sensitive == nil {
+		return nil
+	}
+
+	return result
+}
+
+// ToRegistryRuleSet converts a rule set block, nil when there is none
+func ToRegistryRuleSet(ruleSet []interface{}) *RuleSet {
+	if len(ruleSet) == 0 || ruleSet[0] == nil {
+		return nil
+	}
+	r := ruleSet[0].(map[string]interface{})
+
+	return &RuleSet{
+		DomainRules:    toRegistryRules(r["domain_rules"].([]interface{})),
+		MigrationRules: toRegistryRules(r["migration_rules"].([]interface{})),
+	}
+}
+
+func toRegistryRules(rules []interface{}) []Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		r := rule.(map[string]interface{})
+
+		var params map[string]string
+		for key, value := range r["params"].(map[string]interface{}) {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[key] = value.(string)
+		}
+
+		var tags []string
+		if t := r["tags"].(*schema.Set).List(); len(t) > 0 {
+			tags = sortedStrings(t)
+		}
+
+		result = append(result, Rule{
+			Name:      r["name"].(string),
+			Kind:      r["kind"].(string),
+			Mode:      r["mode"].(string),
+			Type:      r["type"].(string),
+			Tags:      tags,
+			Params:    params,
+			Expr:      r["expr"].(string),
+			OnSuccess: r["on_success"].(string),
+			OnFailure: r["on_failure"].(string),
+			Disabled:  r["disabled"].(bool),
+		})
+	}
+
+	return result
+}
+
+// FromRegistryRuleSet converts a rule set to a rule set block
+func FromRegistryRuleSet(ruleSet *RuleSet) []interface{} {
+	ruleSet = normalizeRuleSet(ruleSet)
+	if ruleSet == nil {
+		return make([]interface{}, 0)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"domain_rules":    fromRegistryRules(ruleSet.DomainRules),
+			"migration_rules": fromRegistryRules(ruleSet.MigrationRules),
+		},
+	}
+}
+
+func fromRegistryRules(rules []Rule) []interface{} {
+	result := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		params := make(map[string]interface{}, len(rule.Params))
+		for key, value := range rule.Params {
+			params[key] = value
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":       rule.Name,
+			"kind":       rule.Kind,
+			"mode":       rule.Mode,
+			"type":       rule.Type,
+			"expr":       rule.Expr,
+			"params":     params,
+			"on_success": rule.OnSuccess,
+			"on_failure": rule.OnFailure,
+			"disabled":   rule.Disabled,
+			"tags":       stringsToInterfaces(rule.Tags),
+		})
+	}
+
+	return result
+}
+
+// ruleSetEqual reports whether two schemas have the same rules, ignoring the order of tags
+func ruleSetEqual(r1 *RuleSet, r2 *RuleSet) bool {
+	return reflect.DeepEqual(normalizeRuleSet(r1), normalizeRuleSet(r2))
+}
+
+// withoutInheritedRuleSet leaves out of a rule set the rules that inherited rule sets add to it, by name, unless
+// configured has a rule with the same name
+func withoutInheritedRuleSet(ruleSet *RuleSet, configured *RuleSet, inherited ...*RuleSet) *RuleSet {
+	ruleSet = normalizeRuleSet(ruleSet)
+	if ruleSet == nil {
+		return nil
+	}
+
+	own := make(map[string]bool)
+	if configured != nil {
+		for _, rule := range append(append([]Rule{}, configured.DomainRules...), configured.MigrationRules...) {
+			own[rule.Name] = true
+		}
+	}
+
+	inheritedNames := make(map[string]bool)
+	for _, r := range inherited {
+		if r == nil {
+			continue
+		}
+		for _, rule := range append(append([]Rule{}, r.DomainRules...), r.MigrationRules...) {
+			inheritedNames[rule.Name] = true
+		}
+	}
+
+	filter := func(rules []Rule) []Rule {
+		var result []Rule
+		for _, rule := range rules {
+			if inheritedNames[rule.Name] && !own[rule.Name] {
+				continue
+			}
+			result = append(result, rule)
+		}
+
+		return result
+	}
+
+	return normalizeRuleSet(&RuleSet{
+		DomainRules:    filter(ruleSet.DomainRules),
+		MigrationRules: filter(ruleSet.MigrationRules),
+	})
+}
+
+// normalizeRuleSet is a rule set with empty collections left out and tags sorted, or nil when it has no rules
+func normalizeRuleSet(ruleSet *RuleSet) *RuleSet {
+	if ruleSet == nil || (len(ruleSet.DomainRules) == 0 && len(ruleSet.MigrationRules) == 0) {
+		return nil
+	}
+
+	normalize := func(rules []Rule) []Rule {
+		if len(rules) == 0 {
+			return nil
+		}
+
+		result := make([]Rule, 0, len(rules))
+		for _, rule := range rules {
+			if len(rule.Tags) == 0 {
+				rule.Tags = nil
+			} else {
+				rule.Tags = sortedStrings(stringsToInterfaces(rule.Tags))
+			}
+			if len(rule.Params) == 0 {
+				rule.Params = nil
+			}
+			result = append(result, rule)
+		}
+
+		return result
+	}
+
+	return &RuleSet{
+		DomainRules:    normalize(ruleSet.DomainRules),
+		MigrationRules: normalize(ruleSet.MigrationRules),
+	}
+}
+
+func sortedStrings(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.(string))
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+
+	return result
+}
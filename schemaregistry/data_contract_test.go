@@ -0,0 +1,171 @@
+package schemaregistry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMetadataRoundTrip(t *testing.T) {
+	metadata := &Metadata{
+		Tags:       map[string][]string{"**.ssn": {"PRIVATE", "PII"}},
+		Properties: map[string]string{"owner": "payments", "email": "payments@example.com"},
+		Sensitive:  []string{"email"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSchema().Schema, map[string]interface{}{
+		"metadata": FromRegistryMetadata(metadata),
+	})
+
+	actual := ToRegistryMetadata(d.Get("metadata").([]interface{}))
+	if !metadataEqual(metadata, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", metadata, actual)
+	}
+	if !reflect.DeepEqual(actual.Tags["**.ssn"], []string{"PII", "PRIVATE"}) {
+		t.Errorf("expected sorted tags, got %v", actual.Tags["**.ssn"])
+	}
+}
+
+func TestMetadataEqual(t *testing.T) {
+	tt := []struct {
+		name     string
+		m1       *Metadata
+		m2       *Metadata
+		expected bool
+	}{
+		{
+			name:     "none",
+			expected: true,
+		},
+		{
+			name:     "empty is none",
+			m1:       &Metadata{},
+			expected: true,
+		},
+		{
+			name:     "registry properties are ignored",
+			m1:       &Metadata{Properties: map[string]string{"owner": "payments"}},
+			m2:       &Metadata{Properties: map[string]string{"owner": "payments", "confluent:version": "2"}},
+			expected: true,
+		},
+		{
+			name:     "only registry properties is none",
+			m2:       &Metadata{Properties: map[string]string{"confluent:version": "2"}},
+			expected: true,
+		},
+		{
+			name:     "tag order is ignored",
+			m1:       &Metadata{Tags: map[string][]string{"**.ssn": {"PII", "PRIVATE"}}},
+			m2:       &Metadata{Tags: map[string][]string{"**.ssn": {"PRIVATE", "PII"}}},
+			expected: true,
+		},
+		{
+			name: "different properties",
+			m1:   &Metadata{Properties: map[string]string{"owner": "payments"}},
+			m2:   &Metadata{Properties: map[string]string{"owner": "orders"}},
+		},
+		{
+			name: "added sensitive property",
+			m1:   &Metadata{Properties: map[string]string{"email": "payments@example.com"}},
+			m2:   &Metadata{Properties: map[string]string{"email": "payments@example.com"}, Sensitive: []string{"email"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := metadataEqual(tc.m1, tc.m2); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRuleSetRoundTrip(t *testing.T) {
+	ruleSet := &RuleSet{
+		DomainRules: []Rule{
+			{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL", Expr: "size(message.ssn) == 9", OnFailure: "DLQ", Params: map[string]string{"dlq.topic": "bad-users"}},
+			{Name: "encrypt", Kind: ruleKindTransform, Mode: "WRITEREAD", Type: "ENCRYPT", Tags: []string{"PII"}, Disabled: true},
+		},
+		MigrationRules: []Rule{
+			{Name: "rename", Kind: ruleKindTransform, Mode: "UPGRADE", Type: "JSONATA", Expr: "$merge([$sift($, function($v, $k) {$k != 'ssn'}), {'socialSecurityNumber': $.'ssn'}])"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSchema().Schema, map[string]interface{}{
+		"ruleset": FromRegistryRuleSet(ruleSet),
+	})
+
+	actual := ToRegistryRuleSet(d.Get("ruleset").([]interface{}))
+	if !reflect.DeepEqual(ruleSet, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", ruleSet, actual)
+	}
+}
+
+func TestRuleSetEqual(t *testing.T) {
+	if !ruleSetEqual(nil, &RuleSet{}) {
+		t.Error("expected an empty rule set to equal none")
+	}
+
+	r1 := &RuleSet{DomainRules: []Rule{{Name: "a", Tags: []string{"PII", "PRIVATE"}, Params: map[string]string{}}}}
+	r2 := &RuleSet{DomainRules: []Rule{{Name: "a", Tags: []string{"PRIVATE", "PII"}}}}
+	if !ruleSetEqual(r1, r2) {
+		t.Error("expected rule sets differing only in tag order and empty params to be equal")
+	}
+
+	r3 := &RuleSet{DomainRules: []Rule{{Name: "a", Tags: []string{"PII", "PRIVATE"}, Disabled: true}}}
+	if ruleSetEqual(r1, r3) {
+		t.Error("expected disabling a rule to make a different rule set")
+	}
+}
+
+func TestWithoutInheritedMetadata(t *testing.T) {
+	registered := &Metadata{
+		Tags:       map[string][]string{"**.ssn": {"PII", "PRIVATE"}},
+		Properties: map[string]string{"owner": "payments", "team": "platform", "confluent:version": "1"},
+	}
+	inherited := &Metadata{
+		Tags:       map[string][]string{"**.ssn": {"PII"}},
+		Properties: map[string]string{"team": "platform"},
+	}
+
+	expected := &Metadata{
+		Tags:       map[string][]string{"**.ssn": {"PRIVATE"}},
+		Properties: map[string]string{"owner": "payments"},
+	}
+	actual := withoutInheritedMetadata(registered, &Metadata{Properties: map[string]string{"owner": "payments"}}, nil, inherited)
+	if !metadataEqual(expected, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", expected, actual)
+	}
+
+	// What the schema configures as well stays
+	configured := &Metadata{Tags: map[string][]string{"**.ssn": {"PII"}}, Properties: map[string]string{"team": "platform"}}
+	if actual = withoutInheritedMetadata(registered, configured, inherited); !metadataEqual(userMetadata(registered), actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", registered, actual)
+	}
+
+	if actual = withoutInheritedMetadata(&Metadata{Properties: map[string]string{"team": "platform"}}, nil, inherited); actual != nil {
+		t.Errorf("expected only inherited metadata to be none, got %#v", actual)
+	}
+}
+
+func TestWithoutInheritedRuleSet(t *testing.T) {
+	own := Rule{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL", Expr: "size(message.ssn) == 9"}
+	encrypt := Rule{Name: "encrypt", Kind: ruleKindTransform, Mode: "WRITEREAD", Type: "ENCRYPT", Tags: []string{"PII"}}
+
+	registered := &RuleSet{DomainRules: []Rule{own, encrypt}}
+	inherited := &RuleSet{DomainRules: []Rule{encrypt}}
+
+	expected := &RuleSet{DomainRules: []Rule{own}}
+	if actual := withoutInheritedRuleSet(registered, expected, inherited); !ruleSetEqual(expected, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", expected, actual)
+	}
+
+	if actual := withoutInheritedRuleSet(registered, registered, inherited); !ruleSetEqual(registered, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", registered, actual)
+	}
+
+	if actual := withoutInheritedRuleSet(inherited, nil, nil, inherited); actual != nil {
+		t.Errorf("expected only inherited rules to be none, got %#v", actual)
+	}
+}
@@ -0,0 +1,111 @@
+package schemaregistry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The states of exporters that preconditions check for, besides paused
+const (
+	exporterStateRunning = "RUNNING"
+	exporterStateError   = "ERROR"
+)
+
+// exporterDestinationURLConfig is the config key of the registry an exporter exports to
+const exporterDestinationURLConfig = "schema.registry.url"
+
+func dataSourceExporterStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceExporterStatusRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the exporter",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the exporter, such as STARTING, RUNNING, PAUSED or ERROR",
+			},
+			"running": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the exporter is running",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the exporter is paused",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the exporter stopped on an error",
+			},
+			"offset": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The offset in the registry's schemas topic the exporter is at",
+			},
+			"timestamp": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "When the exporter was at the offset, in milliseconds since the epoch",
+			},
+			"trace": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error the exporter stopped on, if it failed",
+			},
+			"destination_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the registry the exporter exports to",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The config the exporter connects to the destination registry with, which can include credentials",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceExporterStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := m.(*providerMeta).registry
+	name := d.Get("name").(string)
+
+	status, err := registry.GetExporterStatus(ctx, name)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	config, err := registry.GetExporterConfig(ctx, name)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	d.Set("state", status.State)
+	d.Set("running", status.State == exporterStateRunning)
+	d.Set("paused", status.State == exporterStatePaused)
+	d.Set("failed", status.State == exporterStateError)
+	d.Set("offset", status.Offset)
+	d.Set("timestamp", status.Ts)
+	d.Set("trace", status.Trace)
+	d.Set("destination_url", config[exporterDestinationURLConfig])
+
+	if err = d.Set("config", config); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+
+	return diags
+}
@@ -0,0 +1,40 @@
+package schemaregistry
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceExporterStatus_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := fmt.Sprintf("exporter-%s", u[:8])
+	url := os.Getenv("SCHEMA_REGISTRY_URL")
+
+	config := fmt.Sprintf(fixtureExporter, name, name, fmt.Sprintf("sub%s", u), true, url,
+		os.Getenv("SCHEMA_REGISTRY_USERNAME")+":"+os.Getenv("SCHEMA_REGISTRY_PASSWORD")) + fixtureDataSourceExporterStatus
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "id", name),
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "state", exporterStatePaused),
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "paused", "true"),
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "running", "false"),
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "failed", "false"),
+					resource.TestCheckResourceAttr("data.schemaregistry_exporter_status.test", "destination_url", url),
+				),
+			},
+		},
+	})
+}
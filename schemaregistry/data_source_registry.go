@@ -0,0 +1,147 @@
+package schemaregistry
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The first versions of the registry with normalization and with data contracts
+var (
+	normalizeMinVersion     = [2]int{7, 2}
+	dataContractsMinVersion = [2]int{7, 4}
+)
+
+func dataSourceRegistry() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRegistryRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the registry's cluster, such as lsrc-123456. Empty when the registry doesn't report it",
+			},
+			"kafka_cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the Kafka cluster the registry stores schemas in. Empty when the registry doesn't report it",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the registry, such as 7.5.0. Empty when the registry doesn't report it",
+			},
+			"commit_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The commit the registry was built from",
+			},
+			"schema_types": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The schema types the registry supports, such as AVRO, JSON and PROTOBUF",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"contexts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The contexts the registry has subjects in",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"contexts_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the registry supports contexts",
+			},
+			"normalize_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the registry normalizes schemas, which it does from version 7.2",
+			},
+			"data_contracts_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the registry supports data contract metadata and rules, which it does from version 7.4",
+			},
+		},
+	}
+}
+
+// dataSourceRegistryRead reads what the registry reports about itself. Older registries don't have all the
+// endpoints, what they don't report is left empty and the features it would tell about unsupported.
+func dataSourceRegistryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := m.(*providerMeta).registry
+
+	clusterID, err := registry.GetClusterID(ctx)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	if clusterID != nil {
+		d.Set("cluster_id", clusterID.Scope.Clusters[schemaRegistryClusterKey])
+		d.Set("kafka_cluster_id", clusterID.Scope.Clusters[kafkaClusterKey])
+	}
+
+	version, err := registry.GetServerVersion(ctx)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	if version == nil {
+		version = &ServerVersion{}
+	}
+	d.Set("version", version.Version)
+	d.Set("commit_id", version.CommitID)
+	d.Set("normalize_supported", versionAtLeast(version.Version, normalizeMinVersion))
+	d.Set("data_contracts_supported", versionAtLeast(version.Version, dataContractsMinVersion))
+
+	schemaTypes, err := registry.GetSchemaTypes(ctx)
+	if isNotFound(err) {
+		// Registries from before schema types were added only support Avro
+		schemaTypes, err = []string{"AVRO"}, nil
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	sort.Strings(schemaTypes)
+	if err = d.Set("schema_types", schemaTypes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	contexts, err := registry.GetContexts(ctx)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	d.Set("contexts_supported", err == nil)
+	if err = d.Set("contexts", contexts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(registry.url)
+
+	return diags
+}
+
+// versionAtLeast reports whether a registry version, such as 7.5.0 or 7.4.1-ce, is at least the major and minor
+// version. Versions that can't be parsed are not.
+func versionAtLeast(version string, minVersion [2]int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return false
+	}
+
+	return major > minVersion[0] || (major == minVersion[0] && minor >= minVersion[1])
+}
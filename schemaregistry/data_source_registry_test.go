@@ -0,0 +1,96 @@
+package schemaregistry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceRegistry_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fixtureDataSourceRegistry,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.schemaregistry_registry.test", "id", os.Getenv("SCHEMA_REGISTRY_URL")),
+					resource.TestCheckTypeSetElemAttr("data.schemaregistry_registry.test", "schema_types.*", "AVRO"),
+					resource.TestCheckResourceAttrSet("data.schemaregistry_registry.test", "contexts_supported"),
+					resource.TestCheckResourceAttrSet("data.schemaregistry_registry.test", "normalize_supported"),
+					resource.TestCheckResourceAttrSet("data.schemaregistry_registry.test", "data_contracts_supported"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRegistryRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/metadata/id":
+			w.Write([]byte(`{"scope":{"path":[],"clusters":{"kafka-cluster":"lkc-123456","schema-registry-cluster":"lsrc-123456"}}}`))
+		case "/v1/metadata/version":
+			w.Write([]byte(`{"version":"7.3.2-ce","commitId":"abc123"}`))
+		case "/schemas/types":
+			w.Write([]byte(`["PROTOBUF","AVRO","JSON"]`))
+		default:
+			// Contexts are left out, like a registry without them
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":404,"message":"HTTP 404 Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceRegistry().Schema, map[string]interface{}{})
+	meta := &providerMeta{registry: newRegistryClient(server.URL, "", "")}
+
+	if diags := dataSourceRegistryRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"cluster_id":               "lsrc-123456",
+		"kafka_cluster_id":         "lkc-123456",
+		"version":                  "7.3.2-ce",
+		"commit_id":                "abc123",
+		"contexts_supported":       false,
+		"normalize_supported":      true,
+		"data_contracts_supported": false,
+	}
+	for key, value := range expected {
+		if actual := d.Get(key); actual != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, actual)
+		}
+	}
+	if schemaTypes := d.Get("schema_types").(*schema.Set); schemaTypes.Len() != 3 || !schemaTypes.Contains("JSON") {
+		t.Errorf("expected the three schema types, got %v", schemaTypes.List())
+	}
+	if d.Id() != server.URL {
+		t.Errorf("expected the registry URL as ID, got %s", d.Id())
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	cases := map[string]bool{
+		"7.4.0":    true,
+		"7.4.1-ce": true,
+		"7.5.0":    true,
+		"8.0.0":    true,
+		"7.3.2":    false,
+		"6.2.0":    false,
+		"":         false,
+		"latest":   false,
+	}
+
+	for version, expected := range cases {
+		if actual := versionAtLeast(version, dataContractsMinVersion); actual != expected {
+			t.Errorf("expected versionAtLeast(%q, 7.4) to be %v", version, expected)
+		}
+	}
+}
@@ -18,6 +18,11 @@ func dataSourceSchema() *schema.Resource {
 				Required:    true,
 				Description: "The subject related to the schema",
 			},
+			"resolved_subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The subject the schema is read from, which is the subject an alias points to",
+			},
 			"version": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -67,21 +72,28 @@ func dataSourceSubjectRead(ctx context.Context, d *schema.ResourceData, m interf
 	subject := d.Get("subject").(string)
 	version := d.Get("version").(int)
 
-	client := m.(*srclient.SchemaRegistryClient)
+	// Aliases are resolved so that the schema is read from the subject the alias points to
+	resolved, err := resolveSubjectAlias(ctx, m.(*providerMeta).registry, subject)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	client := m.(*providerMeta).client
 	var schema *srclient.Schema
-	var err error
 
 	if version > 0 {
-		schema, err = client.GetSchemaByVersion(subject, version)
+		schema, err = client.GetSchemaByVersion(resolved, version)
 
 	} else {
-		schema, err = client.GetLatestSchema(subject)
+		schema, err = client.GetLatestSchema(resolved)
 	}
 
 	if err != nil {
-		return diag.FromErr(err)
+		return registryDiagnostics(err, nil)
 	}
 
+	d.Set("resolved_subject", resolved)
+
 	if err = d.Set("schema_id", schema.ID()); err != nil {
 		return diag.FromErr(fmt.Errorf("error in dataSourceSubjectRead with setting schema_id: %w", err))
 	}
@@ -98,7 +110,7 @@ func dataSourceSubjectRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	d.SetId(formatSchemaVersionID(subject))
+	d.SetId(formatSchemaVersionID(subject, 0))
 
 	return diags
 }
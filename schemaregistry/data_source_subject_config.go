@@ -0,0 +1,121 @@
+package schemaregistry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSubjectConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSubjectConfigRead,
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject whose config is read",
+			},
+			"compatibility_level": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The compatibility level new versions of the subject are checked against",
+			},
+			"compatibility_level_inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the compatibility level is the global one, because the subject has none of its own",
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The mode of the subject, such as READWRITE or READONLY",
+			},
+			"mode_inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the mode is the global one, because the subject has none of its own",
+			},
+			"inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the subject has no config of its own, so that all of its config is the global one",
+			},
+			"normalize": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether schemas registered under the subject are normalized",
+			},
+			"alias": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The subject this subject is an alias of, if any",
+			},
+			"compatibility_group": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The metadata property whose value groups versions for compatibility checks",
+			},
+			"default_metadata":  computedOnly(metadataSchema("Metadata schemas registered under the subject start from")),
+			"override_metadata": computedOnly(metadataSchema("Metadata applied over the metadata of schemas registered under the subject")),
+			"default_ruleset":   computedOnly(ruleSetSchema("Rules schemas registered under the subject start from")),
+			"override_ruleset":  computedOnly(ruleSetSchema("Rules applied over the rules of schemas registered under the subject")),
+		},
+	}
+}
+
+// dataSourceSubjectConfigRead reads the config the subject is effectively under, and compares it with the config the
+// subject has of its own to tell what is inherited from the global config
+func dataSourceSubjectConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := m.(*providerMeta).registry
+	subject := d.Get("subject").(string)
+
+	config, err := registry.GetSubjectConfig(ctx, subject, true)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	own, err := registry.GetSubjectConfig(ctx, subject, false)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	mode, err := registry.GetSubjectMode(ctx, subject, true)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	_, err = registry.GetSubjectMode(ctx, subject, false)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	modeInherited := err != nil
+
+	d.Set("compatibility_level", config.CompatibilityLevel)
+	d.Set("compatibility_level_inherited", own == nil || own.CompatibilityLevel == "")
+	d.Set("mode", mode)
+	d.Set("mode_inherited", modeInherited)
+	d.Set("inherited", own == nil)
+	d.Set("normalize", config.Normalize != nil && *config.Normalize)
+	d.Set("alias", config.Alias)
+	d.Set("compatibility_group", config.CompatibilityGroup)
+
+	if err = d.Set("default_metadata", FromRegistryMetadata(config.DefaultMetadata)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("override_metadata", FromRegistryMetadata(config.OverrideMetadata)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("default_ruleset", FromRegistryRuleSet(config.DefaultRuleSet)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("override_ruleset", FromRegistryRuleSet(config.OverrideRuleSet)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subject)
+
+	return diags
+}
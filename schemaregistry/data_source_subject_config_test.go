@@ -0,0 +1,40 @@
+package schemaregistry
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSubjectConfig_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+	unconfigured := fmt.Sprintf("sub%s-unconfigured", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureSubjectConfig, subject, "FULL") + fmt.Sprintf(fixtureDataSourceSubjectConfig, unconfigured),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "id", subject),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "compatibility_level", "FULL"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "compatibility_level_inherited", "false"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "inherited", "false"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "mode_inherited", "true"),
+					resource.TestCheckResourceAttrSet("data.schemaregistry_subject_config.test", "mode"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.test", "override_metadata.#", "1"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.unconfigured", "inherited", "true"),
+					resource.TestCheckResourceAttr("data.schemaregistry_subject_config.unconfigured", "compatibility_level_inherited", "true"),
+					resource.TestCheckResourceAttrSet("data.schemaregistry_subject_config.unconfigured", "compatibility_level"),
+				),
+			},
+		},
+	})
+}
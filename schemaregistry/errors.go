@@ -0,0 +1,112 @@
+package schemaregistry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ashleybill/srclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// registryErrorInfo explains a registry error code
+type registryErrorInfo struct {
+	summary string
+	hint    string
+	// attribute is the attribute the error is reported against, unless the caller knows better
+	attribute string
+}
+
+// registryErrors are the error codes of the schema registry API, and the HTTP status codes it falls back to
+var registryErrors = map[int]registryErrorInfo{
+	40101: {summary: "Unauthorized", hint: "Check the username and password the provider is configured with."},
+	40301: {summary: "Forbidden", hint: "The provider's credentials are not allowed to perform this operation."},
+	40401: {summary: "Subject not found", hint: "The subject doesn't exist, or all of its versions were deleted.", attribute: "subject"},
+	40402: {summary: "Version not found", hint: "The version doesn't exist, or was deleted.", attribute: "version"},
+	40403: {summary: "Schema not found", hint: "The schema isn't registered, or was deleted.", attribute: "schema"},
+	40404: {summary: "Subject soft deleted", hint: "The subject was soft deleted, hard delete it or register a schema to use it again.", attribute: "subject"},
+	40405: {summary: "Subject not soft deleted", hint: "A subject must be soft deleted before it is hard deleted, use delete_mode soft_then_hard.", attribute: "delete_mode"},
+	40406: {summary: "Version soft deleted", hint: "The version was soft deleted.", attribute: "version"},
+	40407: {summary: "Version not soft deleted", hint: "A version must be soft deleted before it is hard deleted, use delete_mode soft_then_hard.", attribute: "delete_mode"},
+	40408: {summary: "Subject compatibility level not configured", hint: "The subject has no compatibility level of its own, it uses the global level."},
+	40409: {summary: "Subject mode not configured", hint: "The subject has no mode of its own, it uses the global mode."},
+	40450: {summary: "Exporter not found", hint: "The exporter doesn't exist, or was deleted.", attribute: "name"},
+	40950: {summary: "Exporter already exists", hint: "Import the existing exporter, or give this one another name.", attribute: "name"},
+	42250: {summary: "Invalid exporter", hint: "Check the exporter's context, subjects and config."},
+	409:   {summary: "Incompatible schema", hint: "The schema is incompatible with an earlier version under the subject's compatibility level. See https://docs.confluent.io/platform/current/schema-registry/fundamentals/schema-evolution.html#compatibility-types.", attribute: "schema"},
+	42201: {summary: "Invalid schema", hint: "The registry could not parse the schema, or resolve its references.", attribute: "schema"},
+	42202: {summary: "Invalid version", hint: "Versions are positive integers or \"latest\".", attribute: "version"},
+	42203: {summary: "Invalid compatibility level", hint: "Compatibility levels are NONE, BACKWARD, BACKWARD_TRANSITIVE, FORWARD, FORWARD_TRANSITIVE, FULL and FULL_TRANSITIVE."},
+	42204: {summary: "Invalid mode", hint: "Modes are READWRITE, READONLY, READONLY_OVERRIDE and IMPORT."},
+	42205: {summary: "Operation not permitted", hint: "The registry, or the subject, is in a mode that doesn't allow the operation."},
+	42206: {summary: "Schema referenced", hint: "Other schemas reference this version, remove their references before deleting it."},
+	50001: {summary: "Registry store error", hint: "The registry failed to read or write its backing store, retry the operation."},
+	50002: {summary: "Registry operation timed out", hint: "Retry the operation."},
+	50003: {summary: "Registry forwarding error", hint: "The registry failed to forward the request to its leader, retry the operation."},
+	401:   {summary: "Unauthorized", hint: "Check the username and password the provider is configured with."},
+	403:   {summary: "Forbidden", hint: "The provider's credentials are not allowed to perform this operation."},
+	404:   {summary: "Not found"},
+	422:   {summary: "Invalid request"},
+	500:   {summary: "Registry error", hint: "Retry the operation."},
+}
+
+// registryErrorCode is the registry error code of an error returned by either client, or 0 when it isn't one
+func registryErrorCode(err error) int {
+	var registryErr *RegistryError
+	if errors.As(err, &registryErr) {
+		return registryErr.Code
+	}
+
+	var srclientErr srclient.Error
+	if errors.As(err, &srclientErr) {
+		return srclientErr.Code
+	}
+
+	return 0
+}
+
+// registryDiagnostics translates an error from the registry into a diagnostic that explains its error code, reported
+// against path, or the attribute the error code usually concerns when path is nil. Other errors are passed through.
+func registryDiagnostics(err error, path cty.Path) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	code := registryErrorCode(err)
+	info, ok := registryErrors[code]
+	if !ok && code > 999 {
+		// Unknown error codes start with the HTTP status code
+		for code > 999 {
+			code /= 10
+		}
+		info, ok = registryErrors[code]
+	}
+
+	if !ok {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: path,
+			},
+		}
+	}
+
+	if path == nil && info.attribute != "" {
+		path = cty.GetAttrPath(info.attribute)
+	}
+
+	detail := err.Error()
+	if info.hint != "" {
+		detail = fmt.Sprintf("%s\n\n%s", detail, info.hint)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       info.summary,
+			Detail:        detail,
+			AttributePath: path,
+		},
+	}
+}
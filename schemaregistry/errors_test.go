@@ -0,0 +1,83 @@
+package schemaregistry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ashleybill/srclient"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestRegistryDiagnostics(t *testing.T) {
+	tt := []struct {
+		name    string
+		err     error
+		path    cty.Path
+		summary string
+		attr    string
+	}{
+		{
+			name:    "subject not found",
+			err:     &RegistryError{StatusCode: http.StatusNotFound, Code: 40401, Message: "Subject 'a' not found."},
+			summary: "Subject not found",
+			attr:    "subject",
+		},
+		{
+			name:    "wrapped srclient error",
+			err:     fmt.Errorf("error getting last schema: %w", srclient.Error{Code: 40403, Message: "Schema not found"}),
+			summary: "Schema not found",
+			attr:    "schema",
+		},
+		{
+			name:    "incompatible schema at a given path",
+			err:     &RegistryError{StatusCode: http.StatusConflict, Code: 409, Message: "Schema being registered is incompatible"},
+			path:    cty.GetAttrPath("version").IndexInt(1).GetAttr("schema"),
+			summary: "Incompatible schema",
+			attr:    "version",
+		},
+		{
+			name:    "invalid schema",
+			err:     &RegistryError{StatusCode: http.StatusUnprocessableEntity, Code: 42201, Message: "Invalid schema"},
+			summary: "Invalid schema",
+			attr:    "schema",
+		},
+		{
+			name:    "unknown code falls back to the status code",
+			err:     &RegistryError{StatusCode: http.StatusInternalServerError, Code: 50099, Message: "Something"},
+			summary: "Registry error",
+		},
+		{
+			name:    "not a registry error",
+			err:     errors.New("connection refused"),
+			summary: "connection refused",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := registryDiagnostics(tc.err, tc.path)
+
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("expected one error, got %v", diags)
+			}
+
+			if diags[0].Summary != tc.summary {
+				t.Errorf("expected summary %q, got %q", tc.summary, diags[0].Summary)
+			}
+
+			var attr string
+			if len(diags[0].AttributePath) > 0 {
+				attr = diags[0].AttributePath[0].(cty.GetAttrStep).Name
+			}
+			if attr != tc.attr {
+				t.Errorf("expected the error on attribute %q, got %q", tc.attr, attr)
+			}
+		})
+	}
+
+	if diags := registryDiagnostics(nil, nil); diags != nil {
+		t.Errorf("expected no diagnostics for no error, got %v", diags)
+	}
+}
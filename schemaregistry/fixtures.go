@@ -8,8 +8,12 @@ import (
 
 const fixtureAvro1 = `{\"type\":\"record\",\"name\":\"userAdded\",\"namespace\":\"akc.test\",\"fields\":[{\"name\":\"firstName\",\"type\":\"string\"}]}`
 const fixtureAvro2 = `{\"type\":\"record\",\"name\":\"userAdded\",\"namespace\":\"akc.test\",\"fields\":[{\"name\":\"firstName\",\"type\":\"string\"},{\"name\":\"lastName\",\"type\":\"string\",\"default\":\"last\"}]}`
+const fixtureAvro1Pretty = `{ \"type\": \"record\", \"name\": \"userAdded\", \"namespace\": \"akc.test\", \"fields\": [ { \"name\": \"firstName\", \"type\": \"string\" } ] }`
+const fixtureAvro1Reordered = `{\"namespace\":\"akc.test\",\"name\":\"userAdded\",\"type\":\"record\",\"fields\":[{\"type\":\"string\",\"name\":\"firstName\"}]}`
 const fixtureAvro3 = `{\"type\":\"record\",\"name\":\"userAdded\",\"namespace\":\"akc.test\",\"fields\":[{\"name\":\"firstName\",\"type\":\"string\"},{\"name\":\"lastName\",\"type\":\"string\"}]}`
 
+const fixtureProtobuf1 = `syntax = \"proto3\";\npackage akc.test;\n\nmessage UserAdded {\n  string first_name = 1;\n}\n`
+
 const fixtureCreateSchema = `
 	resource "schemaregistry_schema" "test" {
 		subject = "%s"
@@ -17,6 +21,90 @@ const fixtureCreateSchema = `
 	}
 `
 
+const fixtureCreateSchemaNormalized = `
+	resource "schemaregistry_schema" "test" {
+		subject   = "%s"
+		schema    = "%s"
+		normalize = true
+	}
+`
+
+const fixtureCreateSchemaDeleteMode = `
+	resource "schemaregistry_schema" "test" {
+		subject     = "%s"
+		schema      = "%s"
+		delete_mode = "%s"
+	}
+`
+
+const fixtureCreateSchemaDeletionProtection = `
+	resource "schemaregistry_schema" "test" {
+		subject             = "%s"
+		schema              = "%s"
+		deletion_protection = %t
+	}
+`
+
+const fixtureCreateSchemaOnExistingVersion = `
+	resource "schemaregistry_schema" "test" {
+		subject             = "%s"
+		schema              = "%s"
+		on_existing_version = "%s"
+	}
+`
+
+const fixtureCreateSchemaPinned = `
+	resource "schemaregistry_schema" "test" {
+		subject     = "%s"
+		schema      = "%s"
+		pin_version = true
+	}
+`
+
+const fixtureCreateSchemaMetadata = `
+	resource "schemaregistry_schema" "test" {
+		subject = "%s"
+		schema  = "%s"
+
+		metadata {
+			tags {
+				path = "**.firstName"
+				tags = ["PII"]
+			}
+			properties = {
+				owner = "%s"
+			}
+		}
+	}
+`
+
+const fixtureCreateSchemaRuleSet = `
+	resource "schemaregistry_schema" "test" {
+		subject = "%s"
+		schema  = "%s"
+
+		ruleset {
+			domain_rules {
+				name       = "checkFirstName"
+				kind       = "CONDITION"
+				mode       = "WRITE"
+				type       = "CEL"
+				expr       = "size(message.firstName) > 0"
+				on_failure = "ERROR"
+				disabled   = %t
+			}
+		}
+	}
+`
+
+const fixtureCreateSchemaType = `
+	resource "schemaregistry_schema" "test" {
+		subject     = "%s"
+		schema      = "%s"
+		schema_type = "%s"
+	}
+`
+
 const fixtureDataSourceSchema = `
 	data "schemaregistry_schema" "test" {
 		subject = schemaregistry_schema.test.subject
@@ -29,6 +117,30 @@ const fixtureImportSchema = `
 	}
 `
 
+const fixtureSubjectOneVersion = `
+	resource "schemaregistry_subject" "test" {
+		subject = "%s"
+
+		version {
+			schema = "%s"
+		}
+	}
+`
+
+const fixtureSubjectTwoVersions = `
+	resource "schemaregistry_subject" "test" {
+		subject = "%s"
+
+		version {
+			schema = "%s"
+		}
+
+		version {
+			schema = "%s"
+		}
+	}
+`
+
 func fixtureDataSourceSchemaBuild(subject string, schema string) string {
 	return fmt.Sprintf("%s%s", fmt.Sprintf(fixtureCreateSchema, subject, schema), fixtureDataSourceSchema)
 }
@@ -88,3 +200,153 @@ func fixtureResourceSchemaWithReferenceBuild(s schemaWithReferenceFixture) strin
 
 	return buf.String()
 }
+
+const fixtureSubjectConfig = `
+	resource "schemaregistry_subject_config" "test" {
+		subject             = "%s"
+		compatibility_level = "%s"
+
+		override_metadata {
+			tags {
+				path = "**.ssn"
+				tags = ["PII"]
+			}
+		}
+	}
+`
+
+const fixtureSubjectConfigLevelOnly = `
+	resource "schemaregistry_subject_config" "test" {
+		subject             = "%s"
+		compatibility_level = "%s"
+	}
+`
+
+const fixtureExporter = `
+	resource "schemaregistry_exporter" "test" {
+		name                  = "%s"
+		context_type          = "CUSTOM"
+		context               = "%s"
+		subjects              = ["%s"]
+		subject_rename_format = "exported-$${subject}"
+		paused                = %t
+
+		config = {
+			"schema.registry.url"           = "%s"
+			"basic.auth.credentials.source" = "USER_INFO"
+		}
+
+		sensitive_config = {
+			"basic.auth.user.info" = "%s"
+		}
+	}
+`
+
+const fixtureDataSourceExporterStatus = `
+	data "schemaregistry_exporter_status" "test" {
+		name = schemaregistry_exporter.test.name
+	}
+`
+
+const fixtureTagBinding = `
+	resource "schemaregistry_tag" "test" {
+		name         = "%s"
+		description  = "%s"
+		entity_types = ["sr_schema", "sr_field"]
+	}
+
+	resource "schemaregistry_schema" "test" {
+		subject = "%s"
+		schema  = "%s"
+	}
+
+	resource "schemaregistry_tag_binding" "test" {
+		entity_type = "sr_schema"
+		entity_name = "%s:.:${schemaregistry_schema.test.schema_id}"
+		tag         = schemaregistry_tag.test.name
+	}
+`
+
+const fixtureBusinessMetadataBinding = `
+	resource "schemaregistry_business_metadata" "test" {
+		name         = "%s"
+		description  = "Who owns the entity"
+		entity_types = ["sr_schema"]
+
+		attribute {
+			name     = "team"
+			optional = false
+		}
+
+		attribute {
+			name       = "email"
+			max_length = 100
+		}
+	}
+
+	resource "schemaregistry_schema" "test" {
+		subject = "%s"
+		schema  = "%s"
+	}
+
+	resource "schemaregistry_business_metadata_binding" "test" {
+		entity_type       = "sr_schema"
+		entity_name       = "%s:.:${schemaregistry_schema.test.schema_id}"
+		business_metadata = schemaregistry_business_metadata.test.name
+
+		attributes = {
+			team  = "%s"
+			email = "payments@example.com"
+		}
+	}
+`
+
+const fixtureDataSourceSubjectConfig = `
+	data "schemaregistry_subject_config" "test" {
+		subject = schemaregistry_subject_config.test.subject
+	}
+
+	data "schemaregistry_subject_config" "unconfigured" {
+		subject = "%s"
+	}
+`
+
+const fixtureSubjectAlias = `
+	resource "schemaregistry_schema" "test" {
+		subject = "%s"
+		schema  = "%s"
+	}
+
+	resource "schemaregistry_subject_alias" "test" {
+		alias   = "%s"
+		subject = schemaregistry_schema.test.subject
+	}
+
+	data "schemaregistry_schema" "test" {
+		subject = schemaregistry_subject_alias.test.alias
+	}
+`
+
+const fixtureSubjectAliasMissing = `
+	resource "schemaregistry_subject_alias" "test" {
+		alias   = "%s"
+		subject = "%s"
+	}
+`
+
+const fixtureDataSourceRegistry = `
+	data "schemaregistry_registry" "test" {}
+`
+
+const fixtureSubjectConfigMetadataOnly = `
+	resource "schemaregistry_subject_config" "test" {
+		subject = "%s"
+
+		override_metadata {
+			tags {
+				path = "**.ssn"
+				tags = ["PII"]
+			}
+		}
+	}
+`
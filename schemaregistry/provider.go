@@ -3,13 +3,43 @@ package schemaregistry
 import (
 	"context"
 	"errors"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/ashleybill/srclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// compatibilityLevels are the compatibility levels the registry supports
+var compatibilityLevels = []string{
+	string(srclient.None),
+	string(srclient.Backward),
+	string(srclient.BackwardTransitive),
+	string(srclient.Forward),
+	string(srclient.ForwardTransitive),
+	string(srclient.Full),
+	string(srclient.FullTransitive),
+}
+
+// providerMeta is what resources and data sources receive as meta
+type providerMeta struct {
+	client   *srclient.SchemaRegistryClient
+	registry *registryClient
+
+	// normalize is the default of the schema resource's normalize argument
+	normalize bool
+
+	// deletionProtection is the default of the schema resource's deletion_protection argument
+	deletionProtection bool
+
+	// localCompatibilityCheck enables checking schema changes offline, at plan time, under localCompatibilityLevel
+	localCompatibilityCheck bool
+	localCompatibilityLevel srclient.CompatibilityLevel
+}
+
 // Provider -
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -29,12 +59,48 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SCHEMA_REGISTRY_PASSWORD", nil),
 			},
+			"normalize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether schemas are normalized when registered and looked up, unless set on the schema resource",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SCHEMA_REGISTRY_DELETION_PROTECTION", true),
+				Description: "Whether schema resources refuse to be destroyed, unless set on the schema resource",
+			},
+			"local_compatibility_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check schema changes for compatibility at plan time without contacting the registry",
+			},
+			"local_compatibility_level": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(srclient.Backward),
+				Description:      "The compatibility level schema changes are checked against when local_compatibility_check is enabled",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(compatibilityLevels, true)),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"schemaregistry_schema": resourceSchema(),
+			"schemaregistry_business_metadata":         resourceBusinessMetadata(),
+			"schemaregistry_business_metadata_binding": resourceBusinessMetadataBinding(),
+			"schemaregistry_exporter":                  resourceExporter(),
+			"schemaregistry_schema":                    resourceSchema(),
+			"schemaregistry_subject":                   resourceSubject(),
+			"schemaregistry_subject_alias":             resourceSubjectAlias(),
+			"schemaregistry_subject_config":            resourceSubjectConfig(),
+			"schemaregistry_tag":                       resourceTag(),
+			"schemaregistry_tag_binding":               resourceTagBinding(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"schemaregistry_schema": dataSourceSchema(),
+			"schemaregistry_schema":          dataSourceSchema(),
+			"schemaregistry_exporter_status": dataSourceExporterStatus(),
+			"schemaregistry_registry":        dataSourceRegistry(),
+			"schemaregistry_subject_config":  dataSourceSubjectConfig(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -55,7 +121,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 			client.SetCredentials(username, password)
 		}
 
-		return client, diags
+		return &providerMeta{
+			client:                  client,
+			registry:                newRegistryClient(url, username, password),
+			normalize:               d.Get("normalize").(bool),
+			deletionProtection:      d.Get("deletion_protection").(bool),
+			localCompatibilityCheck: d.Get("local_compatibility_check").(bool),
+			localCompatibilityLevel: srclient.CompatibilityLevel(strings.ToUpper(d.Get("local_compatibility_level").(string))),
+		}, diags
 	}
 
 	return nil, diag.FromErr(errors.New("invalid credential parameters"))
@@ -48,4 +48,17 @@ func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("SCHEMA_REGISTRY_PASSWORD"); v == "" {
 		t.Fatal("SCHEMA_REGISTRY_PASSWORD must be set for acceptance tests")
 	}
+
+	// Acceptance tests destroy the subjects they create
+	t.Setenv("SCHEMA_REGISTRY_DELETION_PROTECTION", "false")
+}
+
+// testAccPreCheckCatalog skips tests of the catalog API unless the registry has one, which Confluent Cloud registries
+// do. SCHEMA_REGISTRY_CLUSTER_ID is the registry's cluster ID, which qualifies the names of catalog entities.
+func testAccPreCheckCatalog(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("SCHEMA_REGISTRY_CLUSTER_ID"); v == "" {
+		t.Skip("SCHEMA_REGISTRY_CLUSTER_ID must be set for catalog acceptance tests")
+	}
 }
@@ -0,0 +1,190 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The options of business metadata attributes the resource manages
+const (
+	businessMetadataEntityTypesOption = "applicableEntityTypes"
+	businessMetadataMaxLengthOption   = "maxStrLength"
+)
+
+func resourceBusinessMetadata() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: businessMetadataCreate,
+		UpdateContext: businessMetadataUpdate,
+		ReadContext:   businessMetadataRead,
+		DeleteContext: businessMetadataDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the business metadata, such as Ownership",
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "What the business metadata is about",
+			},
+			"entity_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The types of entities the business metadata applies to, such as sr_subject_version or sr_schema. Defaults to any entity",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"attribute": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The attributes entities with the business metadata have, such as owner or cost_center",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the attribute",
+						},
+						"optional": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether bindings can leave the attribute out",
+						},
+						"max_length": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							Description:  "The longest value the attribute takes. Defaults to the registry's limit",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func businessMetadataCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	def := businessMetadataDefFromResourceData(d)
+
+	if err := meta.(*providerMeta).registry.CreateBusinessMetadataDef(ctx, def); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	d.SetId(def.Name)
+
+	return businessMetadataRead(ctx, d, meta)
+}
+
+func businessMetadataUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := meta.(*providerMeta).registry.UpdateBusinessMetadataDef(ctx, businessMetadataDefFromResourceData(d)); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return businessMetadataRead(ctx, d, meta)
+}
+
+func businessMetadataRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	def, err := meta.(*providerMeta).registry.GetBusinessMetadataDef(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Business metadata %s was deleted, removing it from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	d.Set("name", def.Name)
+	d.Set("description", def.Description)
+
+	var entityTypes []string
+	attributes := make([]interface{}, 0, len(def.AttributeDefs))
+	for _, attribute := range def.AttributeDefs {
+		// Every attribute applies to the same entity types, the resource sets them all at once
+		if option, ok := attribute.Options[businessMetadataEntityTypesOption]; ok && entityTypes == nil {
+			if err = json.Unmarshal([]byte(option), &entityTypes); err != nil {
+				return diag.Errorf("error reading the entity types of attribute %s of business metadata %s from %s %q: %v", attribute.Name, def.Name, businessMetadataEntityTypesOption, option, err)
+			}
+		}
+
+		var maxLength int
+		if option, ok := attribute.Options[businessMetadataMaxLengthOption]; ok {
+			if maxLength, err = strconv.Atoi(option); err != nil {
+				return diag.Errorf("error reading the max length of attribute %s of business metadata %s from %s %q: %v", attribute.Name, def.Name, businessMetadataMaxLengthOption, option, err)
+			}
+		}
+		attributes = append(attributes, map[string]interface{}{
+			"name":       attribute.Name,
+			"optional":   attribute.IsOptional,
+			"max_length": maxLength,
+		})
+	}
+
+	if err = d.Set("entity_types", entityTypes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("attribute", attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func businessMetadataDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := meta.(*providerMeta).registry.DeleteBusinessMetadataDef(ctx, d.Id()); err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
+
+// businessMetadataDefFromResourceData builds the business metadata definition the resource configures. Attributes
+// are strings, which is the only type the catalog supports for business metadata.
+func businessMetadataDefFromResourceData(d *schema.ResourceData) BusinessMetadataDef {
+	entityTypes := sortedStrings(d.Get("entity_types").(*schema.Set).List())
+	if len(entityTypes) == 0 {
+		entityTypes = []string{catalogEntityTypeAny}
+	}
+	encodedEntityTypes, _ := json.Marshal(entityTypes)
+
+	attributes := d.Get("attribute").([]interface{})
+	def := BusinessMetadataDef{
+		Name:          d.Get("name").(string),
+		Description:   d.Get("description").(string),
+		AttributeDefs: make([]BusinessMetadataAttributeDef, 0, len(attributes)),
+	}
+
+	for _, attribute := range attributes {
+		a := attribute.(map[string]interface{})
+
+		options := map[string]string{businessMetadataEntityTypesOption: string(encodedEntityTypes)}
+		if maxLength := a["max_length"].(int); maxLength > 0 {
+			options[businessMetadataMaxLengthOption] = strconv.Itoa(maxLength)
+		}
+
+		def.AttributeDefs = append(def.AttributeDefs, BusinessMetadataAttributeDef{
+			Name:        a["name"].(string),
+			TypeName:    "string",
+			IsOptional:  a["optional"].(bool),
+			Cardinality: "SINGLE",
+			Options:     options,
+		})
+	}
+
+	return def
+}
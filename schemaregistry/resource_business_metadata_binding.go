@@ -0,0 +1,135 @@
+package schemaregistry
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBusinessMetadataBinding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: businessMetadataBindingCreate,
+		UpdateContext: businessMetadataBindingUpdate,
+		ReadContext:   businessMetadataBindingRead,
+		DeleteContext: businessMetadataBindingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: businessMetadataBindingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"entity_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of the entity, such as sr_subject_version or sr_schema",
+				ForceNew:    true,
+			},
+			"entity_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The qualified name of the entity, such as <cluster ID>:.:<subject>:<version> for a subject version",
+				ForceNew:    true,
+			},
+			"business_metadata": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the business metadata",
+				ForceNew:    true,
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The values of the attributes of the business metadata",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func businessMetadataBindingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	binding := businessMetadataBindingFromResourceData(d)
+
+	if err := meta.(*providerMeta).registry.CreateBusinessMetadataBinding(ctx, binding); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	d.SetId(formatCatalogBindingID(binding.EntityType, binding.EntityName, binding.TypeName))
+
+	return businessMetadataBindingRead(ctx, d, meta)
+}
+
+func businessMetadataBindingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := meta.(*providerMeta).registry.UpdateBusinessMetadataBinding(ctx, businessMetadataBindingFromResourceData(d)); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return businessMetadataBindingRead(ctx, d, meta)
+}
+
+func businessMetadataBindingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entityType, entityName, name, err := extractCatalogBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	bindings, err := meta.(*providerMeta).registry.GetBusinessMetadataBindings(ctx, entityType, entityName)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	var found *BusinessMetadataBinding
+	for i := range bindings {
+		if bindings[i].TypeName == name {
+			found = &bindings[i]
+		}
+	}
+	if found == nil {
+		log.Printf("[WARN] Business metadata %s was removed from %s %s, removing it from state", name, entityType, entityName)
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("entity_type", entityType)
+	d.Set("entity_name", entityName)
+	d.Set("business_metadata", name)
+
+	if err = d.Set("attributes", found.Attributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func businessMetadataBindingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	binding := businessMetadataBindingFromResourceData(d)
+	if err := meta.(*providerMeta).registry.DeleteBusinessMetadataBinding(ctx, binding); err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
+
+// businessMetadataBindingImport checks the ID is <entity type>___<entity name>___<business metadata> before reading
+func businessMetadataBindingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, _, err := extractCatalogBindingID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// businessMetadataBindingFromResourceData builds the binding the resource configures
+func businessMetadataBindingFromResourceData(d *schema.ResourceData) BusinessMetadataBinding {
+	attributes := make(map[string]string)
+	for key, value := range d.Get("attributes").(map[string]interface{}) {
+		attributes[key] = value.(string)
+	}
+
+	return BusinessMetadataBinding{
+		TypeName:   d.Get("business_metadata").(string),
+		EntityType: d.Get("entity_type").(string),
+		EntityName: d.Get("entity_name").(string),
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,144 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccResourceBusinessMetadata_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Business metadata names are identifiers, without dashes
+	name := "tf" + strings.ReplaceAll(u, "-", "")[:12]
+	subject := fmt.Sprintf("sub%s", u)
+	clusterID := os.Getenv("SCHEMA_REGISTRY_CLUSTER_ID")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheckCatalog(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureBusinessMetadataBinding, name, subject, fixtureAvro1, clusterID, "payments"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata.test", "id", name),
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata.test", "entity_types.#", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata.test", "attribute.#", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata.test", "attribute.0.optional", "false"),
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata.test", "attribute.1.max_length", "100"),
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata_binding.test", "attributes.team", "payments"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(fixtureBusinessMetadataBinding, name, subject, fixtureAvro1, clusterID, "billing"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_business_metadata_binding.test", "attributes.team", "billing"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_business_metadata.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "schemaregistry_business_metadata_binding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBusinessMetadataDefFromResourceData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceBusinessMetadata().Schema, map[string]interface{}{
+		"name":         "Ownership",
+		"entity_types": []interface{}{"sr_schema", "sr_subject_version"},
+		"attribute": []interface{}{
+			map[string]interface{}{"name": "team", "optional": false},
+			map[string]interface{}{"name": "email", "max_length": 100},
+		},
+	})
+
+	entityTypes := `["sr_schema","sr_subject_version"]`
+	expected := BusinessMetadataDef{
+		Name: "Ownership",
+		AttributeDefs: []BusinessMetadataAttributeDef{
+			{
+				Name:        "team",
+				TypeName:    "string",
+				Cardinality: "SINGLE",
+				Options:     map[string]string{businessMetadataEntityTypesOption: entityTypes},
+			},
+			{
+				Name:        "email",
+				TypeName:    "string",
+				IsOptional:  true,
+				Cardinality: "SINGLE",
+				Options: map[string]string{
+					businessMetadataEntityTypesOption: entityTypes,
+					businessMetadataMaxLengthOption:   "100",
+				},
+			},
+		},
+	}
+
+	if actual := businessMetadataDefFromResourceData(d); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", expected, actual)
+	}
+}
+
+func TestBusinessMetadataReadEntityTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/catalog/v1/types/businessmetadatadefs/Ownership":
+			w.Write([]byte(`{"name":"Ownership","attributeDefs":[{"name":"team","typeName":"string","options":{"applicableEntityTypes":"[\"sr_schema\"]","maxStrLength":"100"}}]}`))
+		case "/catalog/v1/types/businessmetadatadefs/Untyped":
+			w.Write([]byte(`{"name":"Untyped","attributeDefs":[{"name":"team","typeName":"string","options":{"maxStrLength":"100"}}]}`))
+		case "/catalog/v1/types/businessmetadatadefs/Broken":
+			w.Write([]byte(`{"name":"Broken","attributeDefs":[{"name":"team","typeName":"string","options":{"applicableEntityTypes":"sr_schema"}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{registry: newRegistryClient(server.URL, "", "")}
+
+	d := schema.TestResourceDataRaw(t, resourceBusinessMetadata().Schema, map[string]interface{}{})
+	d.SetId("Ownership")
+	if diags := businessMetadataRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if entityTypes := d.Get("entity_types").(*schema.Set); entityTypes.Len() != 1 || !entityTypes.Contains("sr_schema") {
+		t.Errorf("expected entity types [sr_schema], got %v", entityTypes.List())
+	}
+	if maxLength := d.Get("attribute.0.max_length"); maxLength != 100 {
+		t.Errorf("expected max length 100, got %v", maxLength)
+	}
+
+	// Without the entity types option, the definition reads with no entity types
+	d = schema.TestResourceDataRaw(t, resourceBusinessMetadata().Schema, map[string]interface{}{})
+	d.SetId("Untyped")
+	if diags := businessMetadataRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if entityTypes := d.Get("entity_types").(*schema.Set); entityTypes.Len() != 0 {
+		t.Errorf("expected no entity types, got %v", entityTypes.List())
+	}
+
+	// A malformed entity types option fails the read rather than planning to change entity_types forever
+	d = schema.TestResourceDataRaw(t, resourceBusinessMetadata().Schema, map[string]interface{}{})
+	d.SetId("Broken")
+	if diags := businessMetadataRead(context.Background(), d, meta); !diags.HasError() {
+		t.Error("expected an error reading malformed entity types")
+	}
+}
@@ -0,0 +1,316 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The context types of exporters: AUTO exports into a context named after the source cluster, CUSTOM into the
+// context set on the exporter, and NONE into the default context
+const (
+	exporterContextTypeAuto   = "AUTO"
+	exporterContextTypeCustom = "CUSTOM"
+	exporterContextTypeNone   = "NONE"
+)
+
+// exporterStatePaused is the state of an exporter that was paused
+const exporterStatePaused = "PAUSED"
+
+// exporterCredentialSuffixes end the config keys that hold credentials, such as basic.auth.user.info and
+// ssl.key.password. They are never read back into config, so an imported exporter doesn't show them in plans.
+var exporterCredentialSuffixes = []string{"user.info", "password", "secret", "token"}
+
+func resourceExporter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: exporterCreate,
+		UpdateContext: exporterUpdate,
+		ReadContext:   exporterRead,
+		DeleteContext: exporterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateExporterContext,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the exporter",
+				ForceNew:    true,
+			},
+			"context_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      exporterContextTypeAuto,
+				Description:  "The context subjects are exported into: AUTO, named after the source cluster, CUSTOM, set by context, or NONE",
+				ValidateFunc: validation.StringInSlice([]string{exporterContextTypeAuto, exporterContextTypeCustom, exporterContextTypeNone}, false),
+			},
+			"context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The context subjects are exported into when context_type is CUSTOM",
+			},
+			"subjects": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The subjects to export, which can be wildcards. Defaults to all subjects",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"subject_rename_format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name exported subjects get, where ${subject} is the name of the subject, such as dc_${subject}. In HCL it is escaped as $${subject}",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The config the exporter connects to the destination registry with, such as schema.registry.url",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sensitive_config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Config that is kept out of plans and logs, such as basic.auth.user.info. It is not read back from the registry",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the exporter is paused. Setting it pauses the exporter, unsetting it resumes it from where it stopped",
+			},
+			"reset_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing this value resets the exporter, so that it exports all subjects again from the first schema",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the exporter, such as STARTING, RUNNING, PAUSED or ERROR",
+			},
+			"offset": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The offset in the registry's schemas topic the exporter is at",
+			},
+			"timestamp": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "When the exporter was at the offset, in milliseconds since the epoch",
+			},
+			"trace": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error the exporter stopped on, if it is in the ERROR state",
+			},
+		},
+	}
+}
+
+func exporterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	registry := meta.(*providerMeta).registry
+	exporter := exporterFromResourceData(d)
+
+	if err := registry.CreateExporter(ctx, exporter); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	d.SetId(exporter.Name)
+
+	if d.Get("paused").(bool) {
+		if err := registry.PauseExporter(ctx, exporter.Name); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+	}
+
+	return exporterRead(ctx, d, meta)
+}
+
+// exporterUpdate applies changes while the exporter is paused, since the registry only updates and resets paused
+// exporters, and then leaves it paused or running as configured
+func exporterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	registry := meta.(*providerMeta).registry
+	name := d.Id()
+
+	definitionChanged := d.HasChanges("context_type", "context", "subjects", "subject_rename_format", "config", "sensitive_config")
+	reset := d.HasChange("reset_trigger")
+	wasPaused, _ := d.GetChange("paused")
+	isPaused := wasPaused.(bool)
+
+	if (definitionChanged || reset || d.Get("paused").(bool)) && !isPaused {
+		if err := registry.PauseExporter(ctx, name); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		isPaused = true
+		log.Printf("[INFO] Paused exporter %s", name)
+	}
+
+	if definitionChanged {
+		if err := registry.UpdateExporter(ctx, exporterFromResourceData(d)); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+	}
+
+	if reset {
+		if err := registry.ResetExporter(ctx, name); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		log.Printf("[INFO] Reset exporter %s", name)
+	}
+
+	if isPaused && !d.Get("paused").(bool) {
+		if err := registry.ResumeExporter(ctx, name); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		log.Printf("[INFO] Resumed exporter %s", name)
+	}
+
+	return exporterRead(ctx, d, meta)
+}
+
+func exporterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	name := d.Id()
+
+	exporter, err := registry.GetExporter(ctx, name)
+	if isNotFound(err) {
+		log.Printf("[WARN] Exporter %s was deleted, removing it from state", name)
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	status, err := registry.GetExporterStatus(ctx, name)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	// Sensitive config stays as configured, whatever the registry shows of it
+	sensitive := d.Get("sensitive_config").(map[string]interface{})
+	config := make(map[string]interface{}, len(exporter.Config))
+	for key, value := range exporter.Config {
+		if _, ok := sensitive[key]; !ok && !isExporterCredential(key) {
+			config[key] = value
+		}
+	}
+
+	d.Set("name", name)
+	d.Set("context_type", exporter.ContextType)
+	d.Set("context", exporter.Context)
+	d.Set("subject_rename_format", exporter.SubjectRenameFormat)
+	d.Set("paused", status.State == exporterStatePaused)
+	d.Set("state", status.State)
+	d.Set("offset", status.Offset)
+	d.Set("timestamp", status.Ts)
+	d.Set("trace", status.Trace)
+
+	if err = d.Set("subjects", exporter.Subjects); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("config", config); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// exporterDelete pauses the exporter before deleting it, since the registry only deletes paused exporters. What it
+// exported stays in the destination registry.
+func exporterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	name := d.Id()
+
+	if err := registry.PauseExporter(ctx, name); err != nil {
+		if isNotFound(err) {
+			return diags
+		}
+		return registryDiagnostics(err, nil)
+	}
+
+	if err := registry.DeleteExporter(ctx, name); err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	return diags
+}
+
+// isExporterCredential reports whether an exporter config key holds credentials
+func isExporterCredential(key string) bool {
+	for _, suffix := range exporterCredentialSuffixes {
+		if strings.HasSuffix(strings.ToLower(key), suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exporterFromResourceData builds the exporter the resource configures, with the sensitive config merged into the
+// config
+func exporterFromResourceData(d *schema.ResourceData) Exporter {
+	config := make(map[string]string)
+	for _, key := range []string{"config", "sensitive_config"} {
+		for k, v := range d.Get(key).(map[string]interface{}) {
+			config[k] = v.(string)
+		}
+	}
+
+	subjects := make([]string, 0)
+	for _, subject := range d.Get("subjects").(*schema.Set).List() {
+		subjects = append(subjects, subject.(string))
+	}
+
+	return Exporter{
+		Name:                d.Get("name").(string),
+		ContextType:         d.Get("context_type").(string),
+		Context:             d.Get("context").(string),
+		Subjects:            subjects,
+		SubjectRenameFormat: d.Get("subject_rename_format").(string),
+		Config:              config,
+	}
+}
+
+// validateExporterContext checks at plan time that a context is set exactly when context_type is CUSTOM, that no key
+// is in both config and sensitive_config, and that credentials are in sensitive_config
+func validateExporterContext(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("context_type") && d.NewValueKnown("context") {
+		contextType := d.Get("context_type").(string)
+		raw := d.GetRawConfig()
+		contextSet := !raw.IsNull() && !raw.GetAttr("context").IsNull()
+
+		if contextType == exporterContextTypeCustom && !contextSet {
+			return fmt.Errorf("invalid 'context': a context is required when context_type is %s", exporterContextTypeCustom)
+		}
+		if contextType != exporterContextTypeCustom && contextSet {
+			return fmt.Errorf("invalid 'context': a context is only used when context_type is %s, not %s", exporterContextTypeCustom, contextType)
+		}
+	}
+
+	if d.NewValueKnown("config") && d.NewValueKnown("sensitive_config") {
+		sensitive := d.Get("sensitive_config").(map[string]interface{})
+		for key := range d.Get("config").(map[string]interface{}) {
+			if _, ok := sensitive[key]; ok {
+				return fmt.Errorf("invalid 'sensitive_config': %s is also in config", key)
+			}
+			if isExporterCredential(key) {
+				return fmt.Errorf("invalid 'config': %s holds credentials, set it in sensitive_config", key)
+			}
+		}
+	}
+
+	return nil
+}
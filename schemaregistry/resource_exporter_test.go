@@ -0,0 +1,195 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceExporter_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := fmt.Sprintf("exporter-%s", u[:8])
+	subject := fmt.Sprintf("sub%s", u)
+
+	// The exporter exports into a context of the registry the tests run against
+	config := func(paused bool) string {
+		return fmt.Sprintf(fixtureExporter, name, name, subject, paused, os.Getenv("SCHEMA_REGISTRY_URL"),
+			os.Getenv("SCHEMA_REGISTRY_USERNAME")+":"+os.Getenv("SCHEMA_REGISTRY_PASSWORD"))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "id", name),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "context", name),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "subjects.#", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "subject_rename_format", "exported-${subject}"),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "config.%", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "paused", "false"),
+					resource.TestCheckResourceAttrSet("schemaregistry_exporter.test", "state"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "paused", "true"),
+					resource.TestCheckResourceAttr("schemaregistry_exporter.test", "state", exporterStatePaused),
+				),
+			},
+			{
+				ResourceName:            "schemaregistry_exporter.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"sensitive_config", "offset", "timestamp"},
+			},
+		},
+	})
+}
+
+func TestExporterFromResourceData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceExporter().Schema, map[string]interface{}{
+		"name":             "to-cloud",
+		"subjects":         []interface{}{"payments-*"},
+		"config":           map[string]interface{}{"schema.registry.url": "https://cloud.example.com"},
+		"sensitive_config": map[string]interface{}{"basic.auth.user.info": "key:secret"},
+	})
+
+	expected := Exporter{
+		Name:        "to-cloud",
+		ContextType: exporterContextTypeAuto,
+		Subjects:    []string{"payments-*"},
+		Config: map[string]string{
+			"schema.registry.url":  "https://cloud.example.com",
+			"basic.auth.user.info": "key:secret",
+		},
+	}
+
+	if actual := exporterFromResourceData(d); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", expected, actual)
+	}
+}
+
+func TestIsExporterCredential(t *testing.T) {
+	cases := map[string]bool{
+		"basic.auth.user.info":          true,
+		"bearer.auth.token":             true,
+		"ssl.key.password":              true,
+		"bearer.auth.client.secret":     true,
+		"schema.registry.url":           false,
+		"basic.auth.credentials.source": false,
+	}
+
+	for key, expected := range cases {
+		if actual := isExporterCredential(key); actual != expected {
+			t.Errorf("expected isExporterCredential(%q) to be %v", key, expected)
+		}
+	}
+}
+
+func TestExporterUpdateSequence(t *testing.T) {
+	state := map[string]string{
+		"id":                         "to-cloud",
+		"name":                       "to-cloud",
+		"context_type":               exporterContextTypeAuto,
+		"paused":                     "false",
+		"reset_trigger":              "1",
+		"config.%":                   "1",
+		"config.schema.registry.url": "https://cloud.example.com",
+	}
+	config := map[string]interface{}{
+		"name":          "to-cloud",
+		"reset_trigger": "1",
+		"config":        map[string]interface{}{"schema.registry.url": "https://cloud.example.com"},
+	}
+
+	tt := []struct {
+		name     string
+		change   map[string]interface{}
+		expected []string
+	}{
+		{
+			name:   "definition changed while running",
+			change: map[string]interface{}{"subjects": []interface{}{"payments-*"}},
+			expected: []string{
+				"PUT /exporters/to-cloud/pause",
+				"PUT /exporters/to-cloud",
+				"PUT /exporters/to-cloud/resume",
+			},
+		},
+		{
+			name:   "reset while running",
+			change: map[string]interface{}{"reset_trigger": "2"},
+			expected: []string{
+				"PUT /exporters/to-cloud/pause",
+				"PUT /exporters/to-cloud/reset",
+				"PUT /exporters/to-cloud/resume",
+			},
+		},
+		{
+			name:   "paused",
+			change: map[string]interface{}{"paused": true},
+			expected: []string{
+				"PUT /exporters/to-cloud/pause",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/exporters/to-cloud":
+					w.Write([]byte(`{"name":"to-cloud","contextType":"AUTO","config":{"schema.registry.url":"https://cloud.example.com"}}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/exporters/to-cloud/status":
+					w.Write([]byte(`{"name":"to-cloud","state":"RUNNING"}`))
+				default:
+					calls = append(calls, r.Method+" "+r.URL.Path)
+					w.Write([]byte(`{"name":"to-cloud"}`))
+				}
+			}))
+			defer server.Close()
+
+			meta := &providerMeta{registry: newRegistryClient(server.URL, "", "")}
+			r := resourceExporter()
+
+			changed := make(map[string]interface{}, len(config)+len(tc.change))
+			for k, v := range config {
+				changed[k] = v
+			}
+			for k, v := range tc.change {
+				changed[k] = v
+			}
+
+			prior := &terraform.InstanceState{ID: "to-cloud", Attributes: state}
+			diff, err := r.Diff(context.Background(), prior, terraform.NewResourceConfigRaw(changed), meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, diags := r.Apply(context.Background(), prior, diff, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if !reflect.DeepEqual(tc.expected, calls) {
+				t.Errorf("expected calls:\n%v\n\nactual:\n%v", tc.expected, calls)
+			}
+		})
+	}
+}
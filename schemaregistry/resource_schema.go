@@ -4,14 +4,42 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"terraform-provider-confluent-schema-registry/schemaregistry/compatibility"
 
 	"github.com/ashleybill/srclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The delete_mode values, which decide what destroying a schema resource does to its subject
+const (
+	deleteModeSoft         = "soft"
+	deleteModeHard         = "hard"
+	deleteModeSoftThenHard = "soft_then_hard"
+	deleteModeAbandon      = "abandon"
+	deleteModeRetain       = "retain"
+)
+
+// schemaTypes are the values of schema_type, matched case-insensitively
+var schemaTypes = []string{"avro", "json", "protobuf"}
+
+// latestReferenceVersion is the reference version that resolves to the latest version of the referenced subject
+const latestReferenceVersion = -1
+
+// schemaIDImportPrefix marks an import ID as a schema ID rather than a subject
+const schemaIDImportPrefix = "id:"
+
+// The on_existing_version values, which decide what happens when the configured schema is already registered as a
+// version of the subject that isn't the latest
+const (
+	onExistingVersionReregister = "reregister"
+	onExistingVersionAccept     = "accept"
+	onExistingVersionError      = "error"
 )
 
 func resourceSchema() *schema.Resource {
@@ -21,38 +49,25 @@ func resourceSchema() *schema.Resource {
 		ReadContext:   schemaRead,
 		DeleteContext: schemaDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schemaImport,
 		},
-		CustomizeDiff: customdiff.ComputedIf("version", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-
-			var schemaHasChange bool
-			oldState, newState := d.GetChange("schema")
-
-			if schemaTypeStr, ok := d.Get("schema_type").(string); ok {
-				if strings.ToLower(schemaTypeStr) == "json" {
-					newJSON, _ := structure.NormalizeJsonString(newState)
-					oldJSON, _ := structure.NormalizeJsonString(oldState)
-					schemaHasChange = newJSON != oldJSON
-				} else if strings.ToLower(schemaTypeStr) == "avro" {
-					newJSON, _ := structure.NormalizeJsonString(newState)
-					oldJSON, _ := structure.NormalizeJsonString(oldState)
-					schemaHasChange = newJSON != oldJSON
-				} else if strings.ToLower(schemaTypeStr) == "protobuf" {
-					schemaEquals, err := CompareASTs(newState.(string), oldState.(string))
-					if err != nil {
-						// If theres an error diff should be true, indicating something is wrong?
-						log.Printf("[ERROR] %v", err)
-					}
-
-					schemaHasChange = !schemaEquals
-
-				}
-			}
-			log.Printf("[INFO] Schemas Change %t", schemaHasChange)
-			log.Printf("[INFO] Version Change %t", d.HasChange("version"))
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceSchemaV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSchemaStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		CustomizeDiff: customdiff.All(setNormalizeDefault, setDeletionProtectionDefault, customdiff.ComputedIf("version", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+			oldSchema, newSchema := d.GetChange("schema")
+			schemaHasChange := !SchemasEquivalent(ToSchemaType(d.Get("schema_type")), oldSchema.(string), newSchema.(string))
 
-			return schemaHasChange || d.HasChange("version")
-		}),
+			return schemaHasChange || d.HasChange("version") || d.HasChange("metadata") || d.HasChange("ruleset")
+		}), customdiff.ComputedIf("schema_id", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+			// The same schema with different metadata or rules is a new schema, with its own ID
+			return d.HasChange("metadata") || d.HasChange("ruleset")
+		}), forceNewOnSchemaTypeChange, warnDeletedVersionsOnReplace, validateSchemaDiff, validateRuleSetDiff, checkReferences, validateProtobufSchema, checkCompatibilityLocally),
 		Schema: map[string]*schema.Schema{
 			"subject": {
 				Type:        schema.TypeString,
@@ -61,36 +76,19 @@ func resourceSchema() *schema.Resource {
 				ForceNew:    true,
 			},
 			"schema": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The schema string",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The schema string",
+				ValidateDiagFunc: validateSchemaString,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					var schemaEquals bool
-
-					if schemaTypeStr, ok := d.Get("schema_type").(string); ok {
-						if strings.ToLower(schemaTypeStr) == "json" {
-							newJSON, _ := structure.NormalizeJsonString(new)
-							oldJSON, _ := structure.NormalizeJsonString(old)
-							schemaEquals = newJSON == oldJSON
-						} else if strings.ToLower(schemaTypeStr) == "avro" {
-							newJSON, _ := structure.NormalizeJsonString(new)
-							oldJSON, _ := structure.NormalizeJsonString(old)
-							schemaEquals = newJSON == oldJSON
-						} else if strings.ToLower(schemaTypeStr) == "protobuf" {
-							log.Printf("[INFO] Schema new: \n`%s`", new)
-							log.Printf("[INFO] Schema old: \n`%s`", old)
-							var err error
-							schemaEquals, err = CompareASTs(new, old)
-							if err != nil {
-								// If theres an error diff should be true, indicating something is wrong?
-								log.Printf("[ERROR] schema %v", err)
-							}
-						}
-					}
-
-					return schemaEquals
+					return SchemasEquivalent(ToSchemaType(d.Get("schema_type")), old, new)
 				},
 			},
+			"registered_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The schema string as rendered by the registry",
+			},
 			"schema_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -104,32 +102,96 @@ func resourceSchema() *schema.Resource {
 			"reference": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "The referenced schema list",
+				Description: "The referenced schemas, in the order the registry resolves them. Reordering them alone is not a change",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The referenced schema name",
+							DiffSuppressFunc: suppressReferenceOrder,
+						},
+						"subject": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The referenced schema subject",
+							DiffSuppressFunc: suppressReferenceOrder,
+						},
+						"version": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							Description:      "The referenced schema version, or -1 for the latest version at the time of apply",
+							ValidateFunc:     validation.Any(validation.IntInSlice([]int{latestReferenceVersion}), validation.IntAtLeast(1)),
+							DiffSuppressFunc: suppressReferenceOrder,
+						},
+					},
+				},
+			},
+			"metadata": metadataSchema("The data contract metadata of the schema. Changing it registers a new version"),
+			"ruleset":  ruleSetSchema("The data contract rules of the schema. Changing them registers a new version"),
+			"resolved_references": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The references the schema was registered with, with latest (-1) versions resolved",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Computed:    true,
 							Description: "The referenced schema name",
 						},
 						"subject": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Computed:    true,
 							Description: "The referenced schema subject",
 						},
 						"version": {
 							Type:        schema.TypeInt,
-							Required:    true,
+							Computed:    true,
 							Description: "The referenced schema version",
 						},
 					},
 				},
 			},
 			"schema_type": {
-				Type:        schema.TypeString,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The schema type: avro, json or protobuf",
+				Default:          "avro",
+				ValidateFunc:     validation.StringInSlice(schemaTypes, true),
+				DiffSuppressFunc: suppressSchemaTypeCase,
+			},
+			"normalize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the schema is normalized when registered and looked up, so that semantically identical schemas share an ID. Defaults to the provider's normalize setting",
+			},
+			"pin_version": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the resource tracks the version it registered instead of the latest version of the subject",
+			},
+			"on_existing_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onExistingVersionReregister,
+				Description:  "What to do when the schema is already registered as an older version of the subject: reregister it as the latest version, accept the existing version as-is, or error",
+				ValidateFunc: validation.StringInSlice([]string{onExistingVersionReregister, onExistingVersionAccept, onExistingVersionError}, false),
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "The schema type",
-				Default:     "avro",
+				Computed:    true,
+				Description: "Whether destroying the resource fails instead of deleting the subject. Defaults to the provider's deletion_protection setting",
+			},
+			"delete_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          deleteModeSoftThenHard,
+				Description:      "What destroying the resource does to the subject: soft, hard (only versions already soft deleted), soft_then_hard, or abandon/retain to leave it in the registry",
+				ValidateDiagFunc: validateDeleteMode,
 			},
 		},
 	}
@@ -139,23 +201,18 @@ func schemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 	var diags diag.Diagnostics
 
 	subject := d.Get("subject").(string)
-	schemaString := d.Get("schema").(string)
-	references := ToRegistryReferences(d.Get("reference").([]interface{}))
-	schemaType := ToSchemaType(d.Get("schema_type"))
-
-	client := meta.(*srclient.SchemaRegistryClient)
 
-	schema, err := client.CreateSchema(subject, schemaString, schemaType, references...)
+	schema, err := registerSchemaVersion(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return registryDiagnostics(err, nil)
 	}
 
-	d.SetId(formatSchemaVersionID(subject))
-	d.Set("schema_id", schema.ID())
-	d.Set("schema", schema.Schema())
-	d.Set("version", schema.Version())
+	d.SetId(formatSchemaVersionID(subject, pinnedVersion(d, schema.Version)))
+	d.Set("schema_id", schema.ID)
+	d.Set("version", schema.Version)
+	d.Set("registered_schema", schema.Schema)
 
-	if err = d.Set("reference", FromRegistryReferences(schema.References())); err != nil {
+	if err = setReferences(d, schema.References); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -165,74 +222,470 @@ func schemaCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 func schemaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	// Arguments that only matter on destroy are kept in state without contacting the registry
+	if !d.HasChanges("schema", "schema_type", "reference", "resolved_references", "metadata", "ruleset", "normalize", "pin_version") {
+		return diags
+	}
+
+	schema, err := registerSchemaVersion(ctx, d, meta)
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	d.SetId(formatSchemaVersionID(d.Get("subject").(string), pinnedVersion(d, schema.Version)))
+	d.Set("schema_id", schema.ID)
+	d.Set("version", schema.Version)
+	d.Set("registered_schema", schema.Schema)
+
+	if err = setReferences(d, schema.References); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// registerSchemaVersion makes the configured schema a version of the subject. Registering a schema that already
+// exists just returns the existing version, so when that version isn't the latest the on_existing_version policy
+// decides whether it is reregistered as the latest, kept as-is, or refused.
+func registerSchemaVersion(ctx context.Context, d *schema.ResourceData, meta interface{}) (*SchemaResponse, error) {
 	subject := d.Get("subject").(string)
-	schemaString := d.Get("schema").(string)
-	references := ToRegistryReferences(d.Get("reference").([]interface{}))
-	schemaType := ToSchemaType(d.Get("schema_type"))
-	currentSchemaId := d.Get("schema_id").(int)
-	client := meta.(*srclient.SchemaRegistryClient)
-
-	// This CreateSchema call does not fail if the schema already exists -- it just returns the schema.
-	// This isn't ideal because if we update a schema with an OLD schema string, it will just return that old version
-	// without updating the newest version to that version.
-	// This results in a permanent diff in terraform -- because the latest schema is not matching what is in our new terraform.
-	schema, err := client.CreateSchema(subject, schemaString, schemaType, references...)
+	registry := meta.(*providerMeta).registry
+
+	request, err := schemaRequest(ctx, d, registry)
 	if err != nil {
-		if strings.Contains(err.Error(), "409") {
-			return diag.FromErr(fmt.Errorf("invalid 'schema': Incompatible. Please check the compatability level of your schema and compare it against the allowed actions found here: https://docs.confluent.io/cloud/current/sr/fundamentals/schema-evolution.html#compatibility-types."))
+		return nil, err
+	}
+
+	existing, err := registry.LookupSchema(ctx, subject, request, d.Get("normalize").(bool))
+	if isNotFound(err) {
+		return registerSchema(ctx, d, meta)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The same schema with different metadata or rules is registered as a new version
+	metadata, ruleSet, err := ownDataContract(ctx, registry, subject, existing, request)
+	if err != nil {
+		return nil, err
+	}
+	if !metadataEqual(request.Metadata, metadata) || !ruleSetEqual(request.RuleSet, ruleSet) {
+		return registerSchema(ctx, d, meta)
+	}
+
+	versions, err := registry.GetVersions(ctx, subject, false)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := 0
+	for _, version := range versions {
+		if version > latest {
+			latest = version
 		}
-		return diag.FromErr(err)
 	}
 
-	// If the schema returned from the above call is that of an EXISTING schema, we now do a soft delete on the old
-	//schema and then recreate it, so that the "old" version is now the most updated version and our state matches
-	//(soft delete just de-registers if from the subject it i think? but it still exists)
-	if schema.ID() < currentSchemaId {
-		err = client.DeleteSubjectByVersion(subject, schema.Version(), false)
+	// A pinned resource manages the version it is registered as, whether or not it is the latest
+	if existing.Version == latest || d.Get("pin_version").(bool) {
+		return existing, nil
+	}
+
+	switch policy := d.Get("on_existing_version").(string); policy {
+	case onExistingVersionAccept:
+		log.Printf("[INFO] Schema is version %d of subject %s, the latest is %d, accepting it as-is", existing.Version, subject, latest)
+		return existing, nil
+	case onExistingVersionError:
+		return nil, fmt.Errorf("invalid 'schema': already registered as version %d of subject %s, which is not the latest version (%d). Set on_existing_version to %s to register it as the latest version, or to %s to keep version %d", existing.Version, subject, latest, onExistingVersionReregister, onExistingVersionAccept, existing.Version)
+	}
+
+	// The registry only registers the schema again once the existing version is gone, so it is soft deleted first,
+	// unless another schema depends on it
+	ids, err := registry.GetReferencedBy(ctx, subject, existing.Version)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		return nil, fmt.Errorf("invalid 'schema': already registered as version %d of subject %s, which can't be reregistered as the latest version because schemas %v reference it. Set on_existing_version to %s to keep version %d", existing.Version, subject, ids, onExistingVersionAccept, existing.Version)
+	}
+
+	if err = registry.DeleteVersion(ctx, subject, existing.Version, false); err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Soft deleted version %d of subject %s to reregister it as the latest version", existing.Version, subject)
+
+	return registerSchema(ctx, d, meta)
+}
+
+// pinnedVersion is the version in the ID of the resource, which is only set when pin_version is
+func pinnedVersion(d *schema.ResourceData, version int) int {
+	if d.Get("pin_version").(bool) {
+		return version
+	}
+	return 0
+}
+
+// registerSchema registers the configured schema and looks up the version it was registered as, normalizing it
+// first when the normalize argument is set
+func registerSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) (*SchemaResponse, error) {
+	subject := d.Get("subject").(string)
+	normalize := d.Get("normalize").(bool)
+	registry := meta.(*providerMeta).registry
+
+	request, err := schemaRequest(ctx, d, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := registry.RegisterSchema(ctx, subject, request, normalize)
+	if err != nil {
+		if isConflict(err) {
+			return nil, incompatibleSchemaError(ctx, registry, subject, request, normalize, err)
+		}
+		return nil, err
+	}
+
+	registered, err := registry.LookupSchema(ctx, subject, request, normalize)
+	if err != nil || registered.ID == id {
+		return registered, err
+	}
+
+	// The lookup can match an older version that only differs in metadata or rules, the new version is the one with the ID
+	versions, err := registry.GetSubjectVersionsByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := 0
+	for _, version := range versions {
+		if version.Subject == subject && version.Version > latest {
+			latest = version.Version
+		}
+	}
+	if latest == 0 {
+		return registered, nil
+	}
+
+	return registry.GetSchemaByVersion(ctx, subject, strconv.Itoa(latest))
+}
+
+// schemaRequest builds the register and lookup request for the configured schema, resolving references to the
+// latest version of a subject
+func schemaRequest(ctx context.Context, d *schema.ResourceData, registry *registryClient) (SchemaRequest, error) {
+	references, err := resolveReferences(ctx, registry, referencesFromList(d.Get("reference")))
+	if err != nil {
+		return SchemaRequest{}, err
+	}
+
+	request := NewSchemaRequest(d.Get("schema").(string), ToSchemaType(d.Get("schema_type")), references)
+	request.Metadata = ToRegistryMetadata(d.Get("metadata").([]interface{}))
+	request.RuleSet = ToRegistryRuleSet(d.Get("ruleset").([]interface{}))
+
+	return request, nil
+}
+
+// ownDataContract is the metadata and rules of a registered schema, without what the default and override metadata
+// and rules of its subject's config add to every schema registered under it, unless the request sets it as well
+func ownDataContract(ctx context.Context, registry *registryClient, subject string, registered *SchemaResponse, request SchemaRequest) (*Metadata, *RuleSet, error) {
+	if registered.Metadata == nil && registered.RuleSet == nil {
+		return nil, nil, nil
+	}
+
+	config, err := registry.GetSubjectConfig(ctx, subject, true)
+	if isNotFound(err) {
+		return registered.Metadata, registered.RuleSet, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := withoutInheritedMetadata(registered.Metadata, request.Metadata, config.DefaultMetadata, config.OverrideMetadata)
+	ruleSet := withoutInheritedRuleSet(registered.RuleSet, request.RuleSet, config.DefaultRuleSet, config.OverrideRuleSet)
+
+	return metadata, ruleSet, nil
+}
+
+// setDataContract records the metadata and rules of a registered schema that are the schema's own
+func setDataContract(ctx context.Context, d *schema.ResourceData, registry *registryClient, registered *SchemaResponse) error {
+	configured := SchemaRequest{
+		Metadata: ToRegistryMetadata(d.Get("metadata").([]interface{})),
+		RuleSet:  ToRegistryRuleSet(d.Get("ruleset").([]interface{})),
+	}
+
+	metadata, ruleSet, err := ownDataContract(ctx, registry, d.Get("subject").(string), registered, configured)
+	if err != nil {
+		return err
+	}
+
+	if err = d.Set("metadata", FromRegistryMetadata(metadata)); err != nil {
+		return err
+	}
+
+	return d.Set("ruleset", FromRegistryRuleSet(ruleSet))
+}
+
+// resolveReferences replaces references to the latest version (-1) of a subject with the version that is the latest
+// now
+func resolveReferences(ctx context.Context, registry *registryClient, references []srclient.Reference) ([]srclient.Reference, error) {
+	resolved := make([]srclient.Reference, 0, len(references))
+	for _, reference := range references {
+		if reference.Version == latestReferenceVersion {
+			latest, err := registry.GetSchemaByVersion(ctx, reference.Subject, "latest")
+			if err != nil {
+				return nil, fmt.Errorf("error resolving the latest version of subject %s, referenced as %s: %w", reference.Subject, reference.Name, err)
+			}
+			reference.Version = latest.Version
+		}
+		resolved = append(resolved, reference)
+	}
+
+	return resolved, nil
+}
+
+// referencesFromList converts the reference list, in the order it is configured. The registry resolves references in
+// that order, and it is part of the identity of a registered schema.
+func referencesFromList(references interface{}) []srclient.Reference {
+	return ToRegistryReferences(references.([]interface{}))
+}
+
+// suppressReferenceOrder ignores references that were only reordered, so that moving a reference in the list doesn't
+// register a new version. Requests keep the configured order.
+func suppressReferenceOrder(k, old, new string, d *schema.ResourceData) bool {
+	key := k[:strings.LastIndex(k, "reference")+len("reference")]
+	o, n := d.GetChange(key)
+
+	return referencesEquivalent(o.([]interface{}), n.([]interface{}))
+}
+
+// referencesEquivalent reports whether two reference lists have the same references, in any order
+func referencesEquivalent(old []interface{}, new []interface{}) bool {
+	if len(old) != len(new) {
+		return false
+	}
+
+	counts := make(map[srclient.Reference]int)
+	for _, reference := range ToRegistryReferences(old) {
+		counts[reference]++
+	}
+	for _, reference := range ToRegistryReferences(new) {
+		if counts[reference] == 0 {
+			return false
+		}
+		counts[reference]--
+	}
+
+	return true
+}
+
+// setReferences records the references a version was registered with in resolved_references. reference keeps the
+// latest (-1) version of configured references, so that their resolution doesn't show up as a diff.
+func setReferences(d *schema.ResourceData, registered []srclient.Reference) error {
+	if err := d.Set("resolved_references", FromRegistryReferences(registered)); err != nil {
+		return err
+	}
+
+	latest := make(map[string]bool)
+	for _, reference := range referencesFromList(d.Get("reference")) {
+		if reference.Version == latestReferenceVersion {
+			latest[reference.Name+IDSeparator+reference.Subject] = true
+		}
+	}
+
+	references := make([]srclient.Reference, 0, len(registered))
+	for _, reference := range registered {
+		if latest[reference.Name+IDSeparator+reference.Subject] {
+			reference.Version = latestReferenceVersion
+		}
+		references = append(references, reference)
+	}
+
+	return d.Set("reference", FromRegistryReferences(references))
+}
+
+// incompatibleSchemaError explains a rejected schema, adding the reasons the registry gives when asked verbosely
+func incompatibleSchemaError(ctx context.Context, registry *registryClient, subject string, request SchemaRequest, normalize bool, registerErr error) error {
+	var reasons string
+
+	_, messages, err := registry.TestCompatibility(ctx, subject, "latest", request, normalize)
+	if err == nil && len(messages) > 0 {
+		reasons = "\n  - " + strings.Join(messages, "\n  - ")
+	}
+
+	return fmt.Errorf("invalid 'schema': Incompatible. %w%s", registerErr, reasons)
+}
+
+// removeDeletedSubject removes a resource whose subject, or pinned version, was deleted out of band from state, so
+// that it is planned for creation again. Versions that were only soft deleted are pointed out, since registering the
+// schema again doesn't bring back their version numbers.
+func removeDeletedSubject(ctx context.Context, d *schema.ResourceData, registry *registryClient, subject string, version int) diag.Diagnostics {
+	d.SetId("")
+
+	deleted, err := registry.GetVersions(ctx, subject, true)
+	if err != nil && !isNotFound(err) {
+		log.Printf("[WARN] Could not list the deleted versions of subject %s: %v", subject, err)
+	}
+
+	softDeleted := deleted
+	if version > 0 {
+		softDeleted = nil
+		for _, v := range deleted {
+			if v == version {
+				softDeleted = []int{version}
+			}
+		}
+	}
+
+	if len(softDeleted) == 0 {
+		log.Printf("[WARN] Subject %s not found, removing it from state", subject)
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Subject %s was soft deleted", subject),
+			Detail:   fmt.Sprintf("Versions %v of subject %s were soft deleted outside of Terraform, so the resource was removed from state. Registering the schema again creates a new version.", softDeleted, subject),
+		},
+	}
+}
+
+// schemaImport accepts a subject, a pinned subject version as "<subject>___<version>", or "id:<schema id>" for the
+// subject version a schema ID is registered as, and fills in what the registry knows that the read doesn't refresh
+func schemaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*providerMeta)
+
+	if rawID, ok := strings.CutPrefix(d.Id(), schemaIDImportPrefix); ok {
+		id, err := strconv.Atoi(rawID)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, fmt.Errorf("invalid import ID %q, expected %s<schema id>", d.Id(), schemaIDImportPrefix)
 		}
-		schema, err = client.CreateSchema(subject, schemaString, schemaType, references...)
+
+		versions, err := config.registry.GetSubjectVersionsByID(ctx, id)
 		if err != nil {
-			if strings.Contains(err.Error(), "409") {
-				return diag.FromErr(fmt.Errorf("invalid 'schema': Incompatible. Please check the compatability level of your schema and compare it against the allowed actions found here: https://docs.confluent.io/cloud/current/sr/fundamentals/schema-evolution.html#compatibility-types."))
-			}
-			return diag.FromErr(err)
+			return nil, fmt.Errorf("error getting the subject versions of schema %d: %w", id, err)
+		}
+		if len(versions) != 1 {
+			return nil, fmt.Errorf("schema %d is registered as %d subject versions %v, import one of them as <subject>%s<version>", id, len(versions), versions, IDSeparator)
 		}
+
+		return importSchemaVersion(ctx, d, config, versions[0].Subject, versions[0].Version)
 	}
-	d.Set("schema_id", schema.ID())
-	d.Set("schema", schema.Schema())
-	d.Set("version", schema.Version())
 
-	if err = d.Set("reference", FromRegistryReferences(schema.References())); err != nil {
-		return diag.FromErr(err)
+	// A subject whose name looks like a pinned subject version is imported as the subject
+	subject, version := extractSchemaImportID(d.Id(), func(subject string) bool {
+		_, err := config.registry.GetVersions(ctx, subject, false)
+		return err == nil
+	})
+
+	return importSchemaVersion(ctx, d, config, subject, version)
+}
+
+// importSchemaVersion imports the subject, pinned to the version unless it is 0
+func importSchemaVersion(ctx context.Context, d *schema.ResourceData, config *providerMeta, subject string, version int) ([]*schema.ResourceData, error) {
+
+	registryVersion := "latest"
+	if version > 0 {
+		registryVersion = strconv.Itoa(version)
 	}
 
-	return diags
+	registered, err := config.registry.GetSchemaByVersion(ctx, subject, registryVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error getting schema %s: %w", d.Id(), err)
+	}
+
+	d.SetId(formatSchemaVersionID(subject, version))
+	d.Set("subject", subject)
+	d.Set("schema_type", FromRegistrySchemaType(registered.SchemaType))
+	d.Set("pin_version", version > 0)
+	d.Set("normalize", config.normalize)
+	d.Set("deletion_protection", config.deletionProtection)
+	d.Set("delete_mode", deleteModeSoftThenHard)
+	d.Set("on_existing_version", onExistingVersionReregister)
+
+	if err = setDataContract(ctx, d, config.registry, registered); err != nil {
+		return nil, err
+	}
+	if err = setReferences(d, registered.References); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func schemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := meta.(*srclient.SchemaRegistryClient)
-	subject := extractSchemaVersionID(d.Id())
+	registry := meta.(*providerMeta).registry
+	subject := d.Get("subject").(string)
+	version, err := extractSchemaVersionID(d.Id(), subject)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	var err error
 	// before, the provider tried to look up the schema by the schema string.
 	// The issue was that when a terraform apply ran and failed, it was looking for a schema string that didn't exist (before the tf state gets updated even on a failure)
-	// now, we do not use the tf state to refresh -- we get the latest schema from the registry
-	latestSchema, err := client.GetLatestSchema(subject)
+	// now, we do not use the tf state to refresh -- we get the latest schema from the registry, or the pinned version
+	registryVersion := "latest"
+	if version > 0 {
+		registryVersion = strconv.Itoa(version)
+	}
+	latestSchema, err := registry.GetSchemaByVersion(ctx, subject, registryVersion)
+	if isNotFound(err) {
+		return removeDeletedSubject(ctx, d, registry, subject, version)
+	}
+	if err != nil && version > 0 {
+		return registryDiagnostics(fmt.Errorf("error getting version %d of schema: %w", version, err), nil)
+	}
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting last schema: %w", err))
+		return registryDiagnostics(fmt.Errorf("error getting last schema: %w", err), nil)
+	}
+
+	// A schema accepted as an older version stays that version for as long as it is registered
+	if version == 0 && d.Get("on_existing_version").(string) == onExistingVersionAccept && d.Get("schema").(string) != "" && d.Get("schema_id").(int) != latestSchema.ID {
+		request, err := schemaRequest(ctx, d, registry)
+		if err != nil {
+			return registryDiagnostics(err, nil)
+		}
+
+		existing, err := registry.LookupSchema(ctx, subject, request, d.Get("normalize").(bool))
+		if err != nil && !isNotFound(err) {
+			return registryDiagnostics(err, nil)
+		}
+		if err == nil {
+			setSchemaType(d, existing.SchemaType)
+			d.Set("registered_schema", existing.Schema)
+			d.Set("schema_id", existing.ID)
+			d.Set("subject", subject)
+			d.Set("version", existing.Version)
+
+			if err = setDataContract(ctx, d, registry, existing); err != nil {
+				return registryDiagnostics(err, nil)
+			}
+			if err = setReferences(d, existing.References); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return diags
+		}
+	}
+
+	// At this point, the schema read in matches the most recent version found in the kafka ui/registry.
+	// The configured text is kept for as long as the latest version is the one it was registered as, anything else
+	// is drift and the registry's rendering takes its place.
+	if d.Get("schema").(string) == "" || d.Get("schema_id").(int) != latestSchema.ID {
+		d.Set("schema", latestSchema.Schema)
 	}
 
-	// At this point, the schema read in matches the most recent version found in the kafka ui/registry
-	d.Set("schema", latestSchema.Schema())
-	d.Set("schema_id", latestSchema.ID())
+	setSchemaType(d, latestSchema.SchemaType)
+
+	d.Set("registered_schema", latestSchema.Schema)
+	d.Set("schema_id", latestSchema.ID)
 	d.Set("subject", subject)
-	d.Set("version", latestSchema.Version())
+	d.Set("version", latestSchema.Version)
 
-	if err = d.Set("reference", FromRegistryReferences(latestSchema.References())); err != nil {
+	if err = setDataContract(ctx, d, registry, latestSchema); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	if err = setReferences(d, latestSchema.References); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -240,17 +693,338 @@ func schemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 }
 
 func schemaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subject := d.Get("subject").(string)
+	version, err := extractSchemaVersionID(d.Id(), subject)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return deleteSubject(ctx, d, meta, subject, version)
+}
+
+// deleteSubject deletes the subject, or only the version when it isn't 0, as the resource's delete_mode and
+// deletion_protection say
+func deleteSubject(ctx context.Context, d *schema.ResourceData, meta interface{}, subject string, version int) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := meta.(*srclient.SchemaRegistryClient)
-	subject := extractSchemaVersionID(d.Id())
+	registry := meta.(*providerMeta).registry
+	mode := d.Get("delete_mode").(string)
+
+	if mode == deleteModeAbandon || mode == deleteModeRetain {
+		log.Printf("[INFO] Leaving subject %s in the registry, delete_mode is %s", subject, mode)
+		return diags
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Deletion protection is enabled for subject %s", subject),
+				Detail:   "Set deletion_protection = false on the resource and apply before destroying it, or set delete_mode to abandon to only remove it from state.",
+			},
+		}
+	}
+
+	if err := checkNotReferenced(ctx, registry, subject, version); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	if version > 0 {
+		if err := deleteSchemaVersion(ctx, registry, subject, version, mode); err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		return deletedVersionsWarning(subject, []int{version}, mode)
+	}
 
-	err := client.DeleteSubject(subject, true)
+	switch mode {
+	case deleteModeSoft:
+		versions, err := registry.DeleteSubject(ctx, subject, false)
+		if err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		log.Printf("[INFO] Soft deleted versions %v of subject %s", versions, subject)
+		return deletedVersionsWarning(subject, versions, mode)
+	case deleteModeHard:
+		// The registry only hard deletes versions that are soft deleted, so anything still live is refused here
+		// rather than soft deleted on the way
+		live, err := registry.GetVersions(ctx, subject, false)
+		if err != nil && !isNotFound(err) {
+			return registryDiagnostics(err, nil)
+		}
+		if len(live) > 0 {
+			return diag.Errorf("subject %s has versions %v that are not soft deleted, delete_mode %s only deletes soft deleted versions. Use %s to soft delete them first", subject, live, deleteModeHard, deleteModeSoftThenHard)
+		}
+	default:
+		versions, err := registry.DeleteSubject(ctx, subject, false)
+		if err != nil {
+			return registryDiagnostics(err, nil)
+		}
+		log.Printf("[INFO] Soft deleted versions %v of subject %s", versions, subject)
+	}
+
+	versions, err := registry.DeleteSubject(ctx, subject, true)
 	if err != nil {
-		return diag.FromErr(err)
+		return registryDiagnostics(err, nil)
 	}
+	log.Printf("[INFO] Hard deleted versions %v of subject %s", versions, subject)
 
-	return diags
+	return deletedVersionsWarning(subject, versions, mode)
+}
+
+// deletedVersionsWarning reports the versions destroying the resource removed, since the SDK can't show them when the
+// destroy is planned
+func deletedVersionsWarning(subject string, versions []int, mode string) diag.Diagnostics {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Versions of subject %s deleted", subject),
+			Detail:   fmt.Sprintf("Destroying the resource %s versions %v of subject %s, delete_mode is %s.", deleteModeEffect(mode), versions, subject, mode),
+		},
+	}
+}
+
+// deleteSchemaVersion deletes the version a pinned resource manages according to delete_mode, leaving the rest of the
+// subject alone
+func deleteSchemaVersion(ctx context.Context, registry *registryClient, subject string, version int, mode string) error {
+	switch mode {
+	case deleteModeHard:
+		_, err := registry.GetSchemaByVersion(ctx, subject, strconv.Itoa(version))
+		if err == nil {
+			return fmt.Errorf("version %d of subject %s is not soft deleted, delete_mode %s only deletes soft deleted versions. Use %s to soft delete it first", version, subject, deleteModeHard, deleteModeSoftThenHard)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	default:
+		if err := registry.DeleteVersion(ctx, subject, version, false); err != nil {
+			return err
+		}
+		log.Printf("[INFO] Soft deleted version %d of subject %s", version, subject)
+
+		if mode == deleteModeSoft {
+			return nil
+		}
+	}
+
+	if err := registry.DeleteVersion(ctx, subject, version, true); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Hard deleted version %d of subject %s", version, subject)
+
+	return nil
+}
+
+// checkNotReferenced fails when another schema references a live version of the subject, or the given version when it
+// isn't 0, since deleting it would leave that schema unresolvable
+func checkNotReferenced(ctx context.Context, registry *registryClient, subject string, version int) error {
+	versions := []int{version}
+	if version == 0 {
+		var err error
+		versions, err = registry.GetVersions(ctx, subject, false)
+		if isNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, version := range versions {
+		ids, err := registry.GetReferencedBy(ctx, subject, version)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			return fmt.Errorf("version %d of subject %s is referenced by schemas %v, remove the references before deleting it", version, subject, ids)
+		}
+	}
+
+	return nil
+}
+
+// validateProtobufSchema compiles protobuf schemas against their references and the well-known types so that
+// unresolved imports and type errors are reported at plan time instead of by the registry on apply
+func validateProtobufSchema(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if ToSchemaType(d.Get("schema_type")) != srclient.Protobuf || !d.NewValueKnown("schema") {
+		return nil
+	}
+
+	// Without references there is nothing to fetch, validateSchemaDiff already compiled the schema
+	references := referencesFromList(d.Get("reference"))
+	if len(references) == 0 {
+		return nil
+	}
+
+	for _, reference := range references {
+		// The referenced schema is created or updated in this same run, so it can't be fetched yet
+		if reference.Subject == "" || reference.Version == 0 {
+			log.Printf("[INFO] Skipping protobuf compilation, reference %s is not known yet", reference.Name)
+			return nil
+		}
+	}
+
+	config := meta.(*providerMeta)
+
+	references, err := resolveReferences(ctx, config.registry, references)
+	if err != nil {
+		return err
+	}
+
+	imports, err := ProtoImportsFromReferences(config.client, references)
+	if err != nil {
+		return err
+	}
+
+	if _, err = CompileProto(ctx, d.Get("schema").(string), imports); err != nil {
+		return fmt.Errorf("invalid 'schema': %w", err)
+	}
+
+	return nil
+}
+
+// checkReferences checks that referenced versions exist at plan time, instead of the registry rejecting the schema on
+// apply. It also plans a new version when a reference to the latest version (-1) of a subject now resolves to a newer
+// version than the one the schema was registered with.
+func checkReferences(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("reference") {
+		if err := d.SetNewComputed("resolved_references"); err != nil {
+			return err
+		}
+	}
+
+	resolved := make(map[string]int)
+	for _, reference := range ToRegistryReferences(d.Get("resolved_references").([]interface{})) {
+		resolved[reference.Name] = reference.Version
+	}
+
+	registry := meta.(*providerMeta).registry
+	var latestMoved bool
+
+	for _, reference := range referencesFromList(d.Get("reference")) {
+		// The referenced schema is created or updated in this same run, so it can't be fetched yet
+		if reference.Subject == "" || reference.Version == 0 {
+			continue
+		}
+
+		version := strconv.Itoa(reference.Version)
+		if reference.Version == latestReferenceVersion {
+			version = "latest"
+		}
+
+		referenced, err := registry.GetSchemaByVersion(ctx, reference.Subject, version)
+		if isNotFound(err) {
+			return fmt.Errorf("invalid 'reference': version %s of subject %s, referenced as %s, not found", version, reference.Subject, reference.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("error getting version %s of subject %s, referenced as %s: %w", version, reference.Subject, reference.Name, err)
+		}
+
+		if reference.Version == latestReferenceVersion && d.Id() != "" && resolved[reference.Name] != referenced.Version {
+			log.Printf("[INFO] Reference %s now resolves to version %d of subject %s", reference.Name, referenced.Version, reference.Subject)
+			latestMoved = true
+		}
+	}
+
+	if !latestMoved {
+		return nil
+	}
+
+	for _, key := range []string{"resolved_references", "version", "schema_id", "registered_schema"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setNormalizeDefault falls back to the provider's normalize setting when the resource doesn't set it
+func setNormalizeDefault(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if !config.IsNull() && !config.GetAttr("normalize").IsNull() {
+		return nil
+	}
+
+	return d.SetNew("normalize", meta.(*providerMeta).normalize)
+}
+
+// suppressSchemaTypeCase ignores schema_type changes that only change its case
+func suppressSchemaTypeCase(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// forceNewOnSchemaTypeChange replaces the resource when schemaTypeChangeReplaces says so
+func forceNewOnSchemaTypeChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !schemaTypeChangeReplaces(ctx, d, meta) {
+		return nil
+	}
+
+	return d.ForceNew("schema_type")
+}
+
+// schemaTypeChangeReplaces is whether schema_type changes in a way that replaces the resource, which is unless the
+// subject's compatibility level is NONE, the only level under which the registry accepts a version of a different type
+func schemaTypeChangeReplaces(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+	oldType, newType := d.GetChange("schema_type")
+	if d.Id() == "" || strings.EqualFold(oldType.(string), newType.(string)) {
+		return false
+	}
+
+	subject := d.Get("subject").(string)
+	config, err := meta.(*providerMeta).registry.GetSubjectConfig(ctx, subject, true)
+	if err != nil {
+		log.Printf("[WARN] Replacing subject %s to change its schema type, its compatibility level could not be read: %v", subject, err)
+		return true
+	}
+
+	return config.CompatibilityLevel != string(srclient.None)
+}
+
+// setSchemaType refreshes schema_type from the type the registry returned, keeping the configured case
+func setSchemaType(d *schema.ResourceData, registrySchemaType string) {
+	schemaType := FromRegistrySchemaType(registrySchemaType)
+	if !strings.EqualFold(d.Get("schema_type").(string), schemaType) {
+		d.Set("schema_type", schemaType)
+	}
+}
+
+// setDeletionProtectionDefault falls back to the provider's deletion_protection setting when the resource doesn't set it
+func setDeletionProtectionDefault(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if !config.IsNull() && !config.GetAttr("deletion_protection").IsNull() {
+		return nil
+	}
+
+	return d.SetNew("deletion_protection", meta.(*providerMeta).deletionProtection)
+}
+
+// checkCompatibilityLocally checks a schema change against the schema in state under the provider's
+// local_compatibility_level, so that incompatible changes fail the plan in environments that can't reach the registry.
+// Only the schema in state is known offline, so transitive levels are checked against that version alone.
+func checkCompatibilityLocally(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := meta.(*providerMeta)
+	if !config.localCompatibilityCheck || d.Id() == "" || !d.HasChange("schema") || !d.NewValueKnown("schema") {
+		return nil
+	}
+
+	oldSchema, newSchema := d.GetChange("schema")
+	incompatibilities, err := compatibility.Check(config.localCompatibilityLevel, ToSchemaType(d.Get("schema_type")), newSchema.(string), []string{oldSchema.(string)})
+	if err != nil {
+		return fmt.Errorf("error checking compatibility locally: %w", err)
+	}
+
+	if len(incompatibilities) > 0 {
+		return fmt.Errorf("invalid 'schema': Incompatible with the current version under %s compatibility:\n  - %s", config.localCompatibilityLevel, strings.Join(incompatibilities, "\n  - "))
+	}
+
+	return nil
 }
 
 func FromRegistryReferences(references []srclient.Reference) []interface{} {
@@ -284,6 +1058,15 @@ func ToSchemaType(schemaType interface{}) srclient.SchemaType {
 	return returnType
 }
 
+// FromRegistrySchemaType is the schema_type of a schema type returned by the registry, which leaves it out for Avro
+func FromRegistrySchemaType(schemaType string) string {
+	if schemaType == "" {
+		return "avro"
+	}
+
+	return strings.ToLower(schemaType)
+}
+
 func ToRegistryReferences(references []interface{}) []srclient.Reference {
 
 	if len(references) == 0 {
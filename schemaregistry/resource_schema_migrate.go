@@ -0,0 +1,69 @@
+package schemaregistry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSchemaV0 is the schema resource before registered_schema was split out of schema
+func resourceSchemaV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"schema_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"reference": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"subject": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"schema_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "avro",
+			},
+		},
+	}
+}
+
+// resourceSchemaStateUpgradeV0 moves the registry's rendering, which version 0 stored in schema, to registered_schema.
+// schema keeps it until the next apply, where the DiffSuppressFunc treats it as equal to the configured text.
+func resourceSchemaStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	rawState["registered_schema"] = rawState["schema"]
+
+	return rawState, nil
+}
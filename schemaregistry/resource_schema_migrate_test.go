@@ -0,0 +1,39 @@
+package schemaregistry
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceSchemaStateUpgradeV0(t *testing.T) {
+	registered := `{"type":"record","name":"userAdded","namespace":"akc.test","fields":[{"name":"firstName","type":"string"}]}`
+
+	rawState := map[string]interface{}{
+		"id":          "subject",
+		"subject":     "subject",
+		"schema":      registered,
+		"schema_id":   1,
+		"version":     1,
+		"schema_type": "avro",
+	}
+
+	expected := map[string]interface{}{
+		"id":                "subject",
+		"subject":           "subject",
+		"schema":            registered,
+		"registered_schema": registered,
+		"schema_id":         1,
+		"version":           1,
+		"schema_type":       "avro",
+	}
+
+	actual, err := resourceSchemaStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error upgrading state: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%#v\n\nactual:\n%#v", expected, actual)
+	}
+}
@@ -1,13 +1,18 @@
 package schemaregistry
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
 
 	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceSchema_basic(t *testing.T) {
@@ -28,6 +33,30 @@ func TestAccResourceSchema_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("schemaregistry_schema.test", "schema_id"),
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "registered_schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_keepsConfiguredSchema(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchema, subject, fixtureAvro1Pretty),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1Pretty, "\\", "", -1)),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "registered_schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
 				),
 			},
 		},
@@ -106,6 +135,66 @@ func TestAccResourceSchema_updateRevert(t *testing.T) {
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "id", subject),
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "subject", subject),
 					resource.TestCheckResourceAttrSet("schemaregistry_schema.test", "schema_id"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "3"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_onExistingVersionReregister(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "reregister"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro2, "reregister"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "reregister"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "3"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_onExistingVersionAccept(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "accept"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro2, "accept"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "accept"),
+				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
 					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
 				),
@@ -114,6 +203,33 @@ func TestAccResourceSchema_updateRevert(t *testing.T) {
 	})
 }
 
+func TestAccResourceSchema_onExistingVersionError(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "error"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro2, "error"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+			},
+			{
+				Config:      fmt.Sprintf(fixtureCreateSchemaOnExistingVersion, subject, fixtureAvro1, "error"),
+				ExpectError: regexp.MustCompile(`already registered as version 1`),
+			},
+		},
+	})
+}
+
 func TestAccResourceSchema_updateIncompatible(t *testing.T) {
 	u, err := uuid.GenerateUUID()
 	if err != nil {
@@ -143,6 +259,326 @@ func TestAccResourceSchema_updateIncompatible(t *testing.T) {
 	})
 }
 
+func TestAccResourceSchema_normalize(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaNormalized, subject, fixtureAvro1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "normalize", "true"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+				),
+			},
+			{
+				Config:   fmt.Sprintf(fixtureCreateSchemaNormalized, subject, fixtureAvro1Reordered),
+				PlanOnly: true,
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchema, subject, fixtureAvro1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "normalize", "false"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_softDelete(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckSubjectSoftDeleted(subject),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaDeleteMode, subject, fixtureAvro1, "soft"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "delete_mode", "soft"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSubjectSoftDeleted(subject string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		registry := testAccProvider.Meta().(*providerMeta).registry
+
+		if live, err := registry.GetVersions(context.Background(), subject, false); !isNotFound(err) {
+			return fmt.Errorf("expected subject %s to have no live versions, got %v (%v)", subject, live, err)
+		}
+
+		deleted, err := registry.GetVersions(context.Background(), subject, true)
+		if err != nil {
+			return err
+		}
+		if len(deleted) != 1 {
+			return fmt.Errorf("expected subject %s to keep its soft deleted version, got %v", subject, deleted)
+		}
+
+		return nil
+	}
+}
+
+func TestAccResourceSchema_deletionProtection(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaDeletionProtection, subject, fixtureAvro1, true),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      fmt.Sprintf(fixtureCreateSchemaDeletionProtection, subject, fixtureAvro1, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion protection is enabled`),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaDeletionProtection, subject, fixtureAvro1, false),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_pinVersion(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaPinned, subject, fixtureAvro1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "id", subject+IDSeparator+"1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaPinned, subject, fixtureAvro2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "id", subject+IDSeparator+"2"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_schema.test",
+				ImportState:       true,
+				ImportStateId:     subject + IDSeparator + "2",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_metadata(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaMetadata, subject, fixtureAvro1, "payments"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "metadata.0.properties.owner", "payments"),
+					resource.TestCheckTypeSetElemNestedAttrs("schemaregistry_schema.test", "metadata.0.tags.*", map[string]string{
+						"path": "**.firstName",
+					}),
+				),
+			},
+			{
+				// Only the metadata changes, which is a new version of the same schema
+				Config: fmt.Sprintf(fixtureCreateSchemaMetadata, subject, fixtureAvro1, "orders"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "metadata.0.properties.owner", "orders"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_schema.test",
+				ImportState:       true,
+				ImportStateId:     subject,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_ruleSet(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaRuleSet, subject, fixtureAvro1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "ruleset.0.domain_rules.#", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "ruleset.0.domain_rules.0.name", "checkFirstName"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "ruleset.0.domain_rules.0.disabled", "false"),
+				),
+			},
+			{
+				// Only the rules change, which is a new version of the same schema
+				Config: fmt.Sprintf(fixtureCreateSchemaRuleSet, subject, fixtureAvro1, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.test", "ruleset.0.domain_rules.0.disabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_schema.test",
+				ImportState:       true,
+				ImportStateId:     subject,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      strings.Replace(fmt.Sprintf(fixtureCreateSchemaRuleSet, subject, fixtureAvro1, true), `expr       = "size(message.firstName) > 0"`, "", 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule checkFirstName is a CONDITION, which needs an expr`),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_schemaType(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(fixtureCreateSchemaType, subject, fixtureProtobuf1, "protobuff"),
+				ExpectError: regexp.MustCompile(`expected schema_type to be one of`),
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaType, subject, fixtureProtobuf1, "PROTOBUF"),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "schema_type", "PROTOBUF"),
+			},
+			{
+				Config:   fmt.Sprintf(fixtureCreateSchemaType, subject, fixtureProtobuf1, "protobuf"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_importByID(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchemaType, subject, fixtureProtobuf1, "protobuf"),
+			},
+			{
+				ResourceName: "schemaregistry_schema.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return "id:" + s.RootModule().Resources["schemaregistry_schema.test"].Primary.Attributes["schema_id"], nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if id := states[0].ID; id != subject+IDSeparator+"1" {
+						return fmt.Errorf("expected ID %s, got %s", subject+IDSeparator+"1", id)
+					}
+					if schemaType := states[0].Attributes["schema_type"]; schemaType != "protobuf" {
+						return fmt.Errorf("expected schema_type protobuf, got %s", schemaType)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceSchema_deletedOutOfBand(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureCreateSchema, subject, fixtureAvro1),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "1"),
+			},
+			{
+				PreConfig: func() {
+					registry := testAccProvider.Meta().(*providerMeta).registry
+					if _, err := registry.DeleteSubject(context.Background(), subject, false); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             fmt.Sprintf(fixtureCreateSchema, subject, fixtureAvro1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(fixtureCreateSchema, subject, fixtureAvro1),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_schema.test", "version", "2"),
+			},
+		},
+	})
+}
+
 func TestAccResourceSchema_import(t *testing.T) {
 	u, err := uuid.GenerateUUID()
 	if err != nil {
@@ -500,6 +936,104 @@ func TestAccResourceSchemaReferences_updateIncompatible(t *testing.T) {
 	})
 }
 
+func TestAccResourceSchemaReferences_latest(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	referencedSubject := fmt.Sprintf("referencedSub-%s", u)
+	schemaWithReferenceSubject := fmt.Sprintf("sub-%s", u)
+
+	config := func(referencedSchema string) string {
+		return fixtureResourceSchemaWithReferenceBuild(schemaWithReferenceFixture{
+			Referenced: []SchemaResource{
+				{
+					ResourceName: "referencedSchema",
+					Schema:       referencedSchema,
+					Subject:      referencedSubject,
+				},
+			},
+			WithReferences: SchemaResource{
+				ResourceName: "schemaWithReference",
+				Schema:       `[\"akc.test.userAdded\"]`,
+				Subject:      schemaWithReferenceSubject,
+			},
+			References: []Reference{
+				{
+					Name:    "akc.test.userAdded",
+					Subject: "schemaregistry_schema.referencedSchema.subject",
+					Version: "-1",
+				},
+			},
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: config(fixtureAvro1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "reference.0.version", "-1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "resolved_references.#", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "resolved_references.0.version", "1"),
+				),
+			},
+			{
+				// The referenced subject moves on during the apply, the schema follows it on the next one
+				Config: config(fixtureAvro2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.referencedSchema", "version", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "resolved_references.0.version", "1"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(fixtureAvro2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "version", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "reference.0.version", "-1"),
+					resource.TestCheckResourceAttr("schemaregistry_schema.schemaWithReference", "resolved_references.0.version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSchemaReferences_missing(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fixtureResourceSchemaWithReferenceBuild(schemaWithReferenceFixture{
+					WithReferences: SchemaResource{
+						ResourceName: "schemaWithReference",
+						Schema:       `[\"akc.test.userAdded\"]`,
+						Subject:      fmt.Sprintf("sub-%s", u),
+					},
+					References: []Reference{
+						{
+							Name:    "akc.test.userAdded",
+							Subject: fmt.Sprintf("%q", fmt.Sprintf("missing-%s", u)),
+							Version: "1",
+						},
+					},
+				}),
+				ExpectError: regexp.MustCompile(`invalid 'reference': version 1 of subject missing-.* not found`),
+			},
+		},
+	})
+}
+
 func TestAccResourceSchemaReferences_import(t *testing.T) {
 	u, err := uuid.GenerateUUID()
 	if err != nil {
@@ -552,3 +1086,96 @@ func RequiresImportError(resourceName string) *regexp.Regexp {
 	message := "to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information."
 	return regexp.MustCompile(fmt.Sprintf(message, resourceName))
 }
+
+func TestReferencesEquivalent(t *testing.T) {
+	reference := func(name string, version int) interface{} {
+		return map[string]interface{}{"name": name, "subject": name + "-value", "version": version}
+	}
+
+	tt := []struct {
+		name       string
+		old        []interface{}
+		new        []interface{}
+		equivalent bool
+	}{
+		{name: "reordered", old: []interface{}{reference("a", 1), reference("b", 2)}, new: []interface{}{reference("b", 2), reference("a", 1)}, equivalent: true},
+		{name: "same", old: []interface{}{reference("a", 1)}, new: []interface{}{reference("a", 1)}, equivalent: true},
+		{name: "version changed", old: []interface{}{reference("a", 1), reference("b", 2)}, new: []interface{}{reference("b", 3), reference("a", 1)}},
+		{name: "added", old: []interface{}{reference("a", 1)}, new: []interface{}{reference("a", 1), reference("b", 2)}},
+		{name: "duplicated", old: []interface{}{reference("a", 1), reference("b", 2)}, new: []interface{}{reference("a", 1), reference("a", 1)}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := referencesEquivalent(tc.old, tc.new); actual != tc.equivalent {
+				t.Errorf("expected referencesEquivalent to be %v", tc.equivalent)
+			}
+		})
+	}
+}
+
+func TestReferencesFromListKeepsOrder(t *testing.T) {
+	references := referencesFromList([]interface{}{
+		map[string]interface{}{"name": "z.Dependency", "subject": "dependency-value", "version": 1},
+		map[string]interface{}{"name": "a.User", "subject": "user-value", "version": 2},
+	})
+
+	if len(references) != 2 || references[0].Name != "z.Dependency" || references[1].Name != "a.User" {
+		t.Errorf("expected the references in the configured order, got %v", references)
+	}
+}
+
+func TestSchemaTypeChangeReplaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config/unchecked":
+			w.Write([]byte(`{"compatibilityLevel":"NONE"}`))
+		case "/config/checked":
+			w.Write([]byte(`{"compatibilityLevel":"BACKWARD"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error_code":50001,"message":"Error in the backend data store"}`))
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{registry: newRegistryClient(server.URL, "", "")}
+
+	tt := []struct {
+		name       string
+		subject    string
+		schemaType string
+		replaces   bool
+	}{
+		{name: "compatibility level NONE", subject: "unchecked", schemaType: "json"},
+		{name: "compatibility checked", subject: "checked", schemaType: "json", replaces: true},
+		{name: "config unreadable", subject: "unavailable", schemaType: "json", replaces: true},
+		{name: "case only", subject: "checked", schemaType: "AVRO"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var replaces bool
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"subject":     {Type: schema.TypeString, Required: true},
+					"schema_type": {Type: schema.TypeString, Optional: true},
+				},
+				CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+					replaces = schemaTypeChangeReplaces(ctx, d, meta)
+					return nil
+				},
+			}
+
+			prior := &terraform.InstanceState{ID: tc.subject, Attributes: map[string]string{"subject": tc.subject, "schema_type": "avro"}}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"subject": tc.subject, "schema_type": tc.schemaType})
+			if _, err := r.Diff(context.Background(), prior, config, meta); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if replaces != tc.replaces {
+				t.Errorf("expected replaces to be %v", tc.replaces)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSubject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: subjectCreate,
+		UpdateContext: subjectUpdate,
+		ReadContext:   subjectRead,
+		DeleteContext: subjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: subjectImport,
+		},
+		CustomizeDiff: customdiff.All(setNormalizeDefault, setDeletionProtectionDefault, warnDeletedSubjectOnReplace),
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject whose version history is managed",
+				ForceNew:    true,
+			},
+			"version": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The versions of the subject, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schema": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The schema string",
+							ValidateDiagFunc: validateSchemaString,
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								schemaTypeKey := k[:len(k)-len("schema")] + "schema_type"
+								return SchemasEquivalent(ToSchemaType(d.Get(schemaTypeKey)), old, new)
+							},
+						},
+						"schema_type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The schema type: avro, json or protobuf",
+							Default:          "avro",
+							ValidateFunc:     validation.StringInSlice(schemaTypes, true),
+							DiffSuppressFunc: suppressSchemaTypeCase,
+						},
+						"reference": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The referenced schemas, in the order the registry resolves them. Reordering them alone is not a change",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										Description:      "The referenced schema name",
+										DiffSuppressFunc: suppressReferenceOrder,
+									},
+									"subject": {
+										Type:             schema.TypeString,
+										Required:         true,
+										Description:      "The referenced schema subject",
+										DiffSuppressFunc: suppressReferenceOrder,
+									},
+									"version": {
+										Type:             schema.TypeInt,
+										Required:         true,
+										Description:      "The referenced schema version",
+										ValidateFunc:     validation.IntAtLeast(1),
+										DiffSuppressFunc: suppressReferenceOrder,
+									},
+								},
+							},
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number the registry gave the schema",
+						},
+						"schema_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the schema",
+						},
+					},
+				},
+			},
+			"normalize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether schemas are normalized when registered and looked up. Defaults to the provider's normalize setting",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether destroying the resource fails instead of deleting the subject. Defaults to the provider's deletion_protection setting",
+			},
+			"delete_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          deleteModeSoftThenHard,
+				Description:      "What destroying the resource does to the subject: soft, hard (only versions already soft deleted), soft_then_hard, or abandon/retain to leave it in the registry",
+				ValidateDiagFunc: validateDeleteMode,
+			},
+		},
+	}
+}
+
+func subjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("subject").(string))
+
+	return subjectReconcile(ctx, d, meta)
+}
+
+func subjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return subjectReconcile(ctx, d, meta)
+}
+
+// subjectReconcile makes the live versions of the subject match the version blocks in order. Versions the registry
+// already has must match their block, since the history can't be rewritten, blocks past the last live version are
+// registered one after the other, and live versions past the last block are soft deleted.
+func subjectReconcile(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subject := d.Get("subject").(string)
+	normalize := d.Get("normalize").(bool)
+	registry := meta.(*providerMeta).registry
+	blocks := d.Get("version").([]interface{})
+
+	live, err := registry.GetVersions(ctx, subject, false)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	sort.Ints(live)
+
+	versions := make([]interface{}, 0, len(blocks))
+	// Whatever was registered before a failure is recorded, so that the next plan starts from there
+	fail := func(err error, path cty.Path) diag.Diagnostics {
+		d.Set("version", versions)
+		return registryDiagnostics(err, path)
+	}
+
+	for i, block := range blocks {
+		b := block.(map[string]interface{})
+		request := subjectVersionRequest(b)
+		path := cty.GetAttrPath("version").IndexInt(i).GetAttr("schema")
+
+		existing, err := registry.LookupSchema(ctx, subject, request, normalize)
+		if err != nil && !isNotFound(err) {
+			return fail(err, path)
+		}
+
+		if i < len(live) {
+			if err != nil || existing.Version != live[i] {
+				return fail(fmt.Errorf("version %d of subject %s doesn't match version block %d. The registry's history can't be rewritten, update the block to match the registry", live[i], subject, i+1), path)
+			}
+		} else {
+			if err == nil {
+				return fail(fmt.Errorf("version block %d of subject %s is already registered as version %d", i+1, subject, existing.Version), path)
+			}
+
+			if _, err = registry.RegisterSchema(ctx, subject, request, normalize); err != nil {
+				if isConflict(err) {
+					return fail(incompatibleSchemaError(ctx, registry, subject, request, normalize, err), path)
+				}
+				return fail(err, path)
+			}
+
+			if existing, err = registry.LookupSchema(ctx, subject, request, normalize); err != nil {
+				return fail(err, path)
+			}
+			log.Printf("[INFO] Registered version block %d as version %d of subject %s", i+1, existing.Version, subject)
+		}
+
+		versions = append(versions, map[string]interface{}{
+			"schema":      b["schema"],
+			"schema_type": b["schema_type"],
+			"reference":   FromRegistryReferences(existing.References),
+			"version":     existing.Version,
+			"schema_id":   existing.ID,
+		})
+	}
+
+	for _, version := range live[min(len(blocks), len(live)):] {
+		if err = registry.DeleteVersion(ctx, subject, version, false); err != nil {
+			return fail(err, nil)
+		}
+		log.Printf("[INFO] Soft deleted version %d of subject %s, it has no version block", version, subject)
+	}
+
+	if err = d.Set("version", versions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func subjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	subject := d.Id()
+
+	live, err := registry.GetVersions(ctx, subject, false)
+	if isNotFound(err) {
+		return removeDeletedSubject(ctx, d, registry, subject, 0)
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	sort.Ints(live)
+
+	current := d.Get("version").([]interface{})
+	versions := make([]interface{}, 0, len(live))
+	for i, version := range live {
+		registered, err := registry.GetSchemaByVersion(ctx, subject, strconv.Itoa(version))
+		if err != nil {
+			return registryDiagnostics(err, nil)
+		}
+
+		v := map[string]interface{}{
+			"schema":      registered.Schema,
+			"schema_type": FromRegistrySchemaType(registered.SchemaType),
+			"reference":   FromRegistryReferences(registered.References),
+			"version":     registered.Version,
+			"schema_id":   registered.ID,
+		}
+
+		// The configured text is kept for as long as the version is the schema it was registered as
+		if i < len(current) {
+			c := current[i].(map[string]interface{})
+			if c["schema_id"].(int) == registered.ID && c["schema"].(string) != "" {
+				v["schema"] = c["schema"]
+			}
+			if strings.EqualFold(c["schema_type"].(string), v["schema_type"].(string)) {
+				v["schema_type"] = c["schema_type"]
+			}
+		}
+
+		versions = append(versions, v)
+	}
+
+	d.Set("subject", subject)
+	if err = d.Set("version", versions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// subjectImport imports a subject by name, with the arguments the read doesn't refresh at their defaults
+func subjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*providerMeta)
+
+	d.Set("subject", d.Id())
+	d.Set("normalize", config.normalize)
+	d.Set("deletion_protection", config.deletionProtection)
+	d.Set("delete_mode", deleteModeSoftThenHard)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// subjectDelete deletes every version of the subject, the resource manages the whole subject
+func subjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteSubject(ctx, d, meta, d.Id(), 0)
+}
+
+// warnDeletedSubjectOnReplace logs the versions replacing the resource removes, see warnDeletedVersionsOnReplace
+func warnDeletedSubjectOnReplace(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("subject") {
+		return nil
+	}
+
+	logDeletedVersions(ctx, meta, d.Id(), 0, d.Get("delete_mode").(string))
+
+	return nil
+}
+
+// subjectVersionRequest builds the register and lookup request for a version block
+func subjectVersionRequest(block map[string]interface{}) SchemaRequest {
+	return NewSchemaRequest(
+		block["schema"].(string),
+		ToSchemaType(block["schema_type"]),
+		referencesFromList(block["reference"]),
+	)
+}
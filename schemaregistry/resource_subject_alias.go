@@ -0,0 +1,145 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSubjectAlias() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: subjectAliasCreate,
+		UpdateContext: subjectAliasUpdate,
+		ReadContext:   subjectAliasRead,
+		DeleteContext: subjectAliasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject name that points to another subject",
+				ForceNew:    true,
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject the alias points to, which must exist",
+			},
+		},
+	}
+}
+
+func subjectAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alias := d.Get("alias").(string)
+
+	if diags := putSubjectAlias(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	d.SetId(alias)
+
+	return subjectAliasRead(ctx, d, meta)
+}
+
+func subjectAliasUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := putSubjectAlias(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return subjectAliasRead(ctx, d, meta)
+}
+
+// putSubjectAlias points the alias to the subject, after checking the subject exists. The registry accepts aliases
+// of subjects it doesn't have, which would leave clients of the alias failing to find schemas.
+func putSubjectAlias(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	registry := meta.(*providerMeta).registry
+	alias := d.Get("alias").(string)
+	subject := d.Get("subject").(string)
+
+	if alias == subject {
+		return diag.FromErr(fmt.Errorf("invalid 'subject': subject %s can't be an alias of itself", subject))
+	}
+
+	if _, err := registry.GetVersions(ctx, subject, false); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	if err := registry.UpdateSubjectConfig(ctx, alias, SubjectConfig{Alias: subject}); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
+
+func subjectAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	alias := d.Id()
+
+	config, err := meta.(*providerMeta).registry.GetSubjectConfig(ctx, alias, false)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+	if err != nil || config.Alias == "" {
+		log.Printf("[WARN] Subject %s is no longer an alias, removing it from state", alias)
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("alias", alias)
+	d.Set("subject", config.Alias)
+
+	return diags
+}
+
+// subjectAliasDelete removes the alias from the config of the alias subject. The registry can't clear a single field,
+// so the config is deleted and the rest of it, like a compatibility level, is put back.
+func subjectAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	alias := d.Id()
+
+	current, err := registry.GetSubjectConfig(ctx, alias, false)
+	if isNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	// An update can't clear the alias, the config is put back without it
+	kept := *current
+	kept.Alias = ""
+	if err = replaceSubjectConfig(ctx, registry, alias, current, kept); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return diags
+}
+
+// resolveSubjectAlias is the subject an alias points to, or the subject itself when it isn't an alias
+func resolveSubjectAlias(ctx context.Context, registry *registryClient, subject string) (string, error) {
+	config, err := registry.GetSubjectConfig(ctx, subject, false)
+	if isNotFound(err) {
+		return subject, nil
+	}
+	// Credentials that can read schemas aren't always allowed to read config, read the subject as it is then
+	if isAccessDenied(err) {
+		log.Printf("[WARN] Reading subject %s without resolving aliases, its config could not be read: %v", subject, err)
+		return subject, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if config.Alias == "" {
+		return subject, nil
+	}
+
+	return config.Alias, nil
+}
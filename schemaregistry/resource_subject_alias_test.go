@@ -0,0 +1,95 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSubjectAlias_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+	alias := fmt.Sprintf("alias%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureSubjectAlias, subject, fixtureAvro1, alias),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject_alias.test", "id", alias),
+					resource.TestCheckResourceAttr("schemaregistry_subject_alias.test", "subject", subject),
+					resource.TestCheckResourceAttr("data.schemaregistry_schema.test", "subject", alias),
+					resource.TestCheckResourceAttr("data.schemaregistry_schema.test", "resolved_subject", subject),
+					resource.TestCheckResourceAttr("data.schemaregistry_schema.test", "schema", strings.Replace(fixtureAvro1, "\\", "", -1)),
+					resource.TestCheckResourceAttrPair("data.schemaregistry_schema.test", "schema_id", "schemaregistry_schema.test", "schema_id"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_subject_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSubjectAlias_missingSubject(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(fixtureSubjectAliasMissing, fmt.Sprintf("alias%s", u), fmt.Sprintf("missing%s", u)),
+				ExpectError: regexp.MustCompile("Subject not found"),
+			},
+		},
+	})
+}
+
+func TestResolveSubjectAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config/payments":
+			w.Write([]byte(`{"alias":"payments-value"}`))
+		case "/config/orders-value":
+			w.Write([]byte(`{"compatibilityLevel":"FULL"}`))
+		case "/config/restricted-value":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error_code":40301,"message":"User is denied operation on this server"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40408,"message":"Subject does not have subject-level compatibility configured"}`))
+		}
+	}))
+	defer server.Close()
+
+	registry := newRegistryClient(server.URL, "", "")
+
+	for subject, expected := range map[string]string{
+		"payments":         "payments-value",
+		"orders-value":     "orders-value",
+		"accounts-value":   "accounts-value",
+		"restricted-value": "restricted-value",
+	} {
+		if resolved, err := resolveSubjectAlias(context.Background(), registry, subject); err != nil || resolved != expected {
+			t.Errorf("expected %s to resolve to %s, got %q (%v)", subject, expected, resolved, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSubjectConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: subjectConfigCreate,
+		UpdateContext: subjectConfigUpdate,
+		ReadContext:   subjectConfigRead,
+		DeleteContext: subjectConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateSubjectConfigRuleSets,
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject whose config is managed",
+				ForceNew:    true,
+			},
+			"compatibility_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The compatibility level new versions of the subject are checked against. Without it the subject uses the global level",
+				ValidateFunc: validation.StringInSlice(compatibilityLevels, false),
+			},
+			"compatibility_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The metadata property whose value groups versions, so that versions are only checked for compatibility against versions with the same value",
+			},
+			"validate_fields": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether field names are validated when schemas are registered under the subject",
+			},
+			"default_metadata":  metadataSchema("Metadata schemas registered under the subject start from, their own metadata takes precedence"),
+			"override_metadata": metadataSchema("Metadata applied over the metadata of schemas registered under the subject"),
+			"default_ruleset":   ruleSetSchema("Rules schemas registered under the subject start from, their own rules take precedence"),
+			"override_ruleset":  ruleSetSchema("Rules applied over the rules of schemas registered under the subject"),
+		},
+	}
+}
+
+func subjectConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subject := d.Get("subject").(string)
+
+	if diags := putSubjectConfig(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	d.SetId(subject)
+
+	return subjectConfigRead(ctx, d, meta)
+}
+
+func subjectConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := putSubjectConfig(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return subjectConfigRead(ctx, d, meta)
+}
+
+// putSubjectConfig sets the config of the subject. The registry only updates the fields a request sets, so when the
+// configuration leaves out a field the subject has, the subject's config is deleted and put back without it. Fields
+// the resource doesn't manage, like an alias, are put back as they were.
+func putSubjectConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	subject := d.Get("subject").(string)
+	registry := meta.(*providerMeta).registry
+	config := subjectConfigFromResourceData(d)
+
+	current, err := registry.GetSubjectConfig(ctx, subject, false)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	if err == nil {
+		config.Alias = current.Alias
+		config.Normalize = current.Normalize
+
+		if clearsSubjectConfig(current, config) {
+			log.Printf("[INFO] Replacing the config of subject %s to clear fields that are no longer configured", subject)
+			if err = replaceSubjectConfig(ctx, registry, subject, current, config); err != nil {
+				return registryDiagnostics(err, nil)
+			}
+			return nil
+		}
+	}
+
+	if err = registry.UpdateSubjectConfig(ctx, subject, config); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
+
+func subjectConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	subject := d.Id()
+
+	config, err := registry.GetSubjectConfig(ctx, subject, false)
+	if isNotFound(err) {
+		log.Printf("[WARN] Subject %s has no config of its own, removing it from state", subject)
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	d.Set("subject", subject)
+	d.Set("compatibility_level", config.CompatibilityLevel)
+	d.Set("compatibility_group", config.CompatibilityGroup)
+	d.Set("validate_fields", config.ValidateFields != nil && *config.ValidateFields)
+
+	if err = d.Set("default_metadata", FromRegistryMetadata(config.DefaultMetadata)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("override_metadata", FromRegistryMetadata(config.OverrideMetadata)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("default_ruleset", FromRegistryRuleSet(config.DefaultRuleSet)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("override_ruleset", FromRegistryRuleSet(config.OverrideRuleSet)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// subjectConfigDelete deletes the config of the subject, which falls back to the global config. An alias the subject
+// has is put back, since it isn't managed by this resource.
+func subjectConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	registry := meta.(*providerMeta).registry
+	subject := d.Id()
+
+	current, err := registry.GetSubjectConfig(ctx, subject, false)
+	if isNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	kept := SubjectConfig{Alias: current.Alias, Normalize: current.Normalize}
+	if err = replaceSubjectConfig(ctx, registry, subject, current, kept); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return diags
+}
+
+// replaceSubjectConfig deletes the config of the subject and puts config, unless it is empty, in its place, which is
+// the only way to clear fields an update leaves as they are. If putting config fails, the current config is put back
+// rather than leaving the subject on the global config.
+func replaceSubjectConfig(ctx context.Context, registry *registryClient, subject string, current *SubjectConfig, config SubjectConfig) error {
+	if err := registry.DeleteSubjectConfig(ctx, subject); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	if reflect.DeepEqual(config, SubjectConfig{}) {
+		return nil
+	}
+
+	err := registry.UpdateSubjectConfig(ctx, subject, config)
+	if err == nil {
+		return nil
+	}
+
+	if restoreErr := registry.UpdateSubjectConfig(ctx, subject, *current); restoreErr != nil {
+		return fmt.Errorf("%w. Restoring the previous config of subject %s failed as well, so it uses the global config until the next apply: %v", err, subject, restoreErr)
+	}
+
+	return err
+}
+
+// subjectConfigFromResourceData builds the config the resource configures
+func subjectConfigFromResourceData(d *schema.ResourceData) SubjectConfig {
+	validateFields := d.Get("validate_fields").(bool)
+
+	return SubjectConfig{
+		CompatibilityLevel: d.Get("compatibility_level").(string),
+		CompatibilityGroup: d.Get("compatibility_group").(string),
+		ValidateFields:     &validateFields,
+		DefaultMetadata:    ToRegistryMetadata(d.Get("default_metadata").([]interface{})),
+		OverrideMetadata:   ToRegistryMetadata(d.Get("override_metadata").([]interface{})),
+		DefaultRuleSet:     ToRegistryRuleSet(d.Get("default_ruleset").([]interface{})),
+		OverrideRuleSet:    ToRegistryRuleSet(d.Get("override_ruleset").([]interface{})),
+	}
+}
+
+// clearsSubjectConfig reports whether config leaves out a field the current config of the subject sets, which an
+// update can't clear
+func clearsSubjectConfig(current *SubjectConfig, config SubjectConfig) bool {
+	return (current.CompatibilityLevel != "" && config.CompatibilityLevel == "") ||
+		(current.CompatibilityGroup != "" && config.CompatibilityGroup == "") ||
+		(userMetadata(current.DefaultMetadata) != nil && config.DefaultMetadata == nil) ||
+		(userMetadata(current.OverrideMetadata) != nil && config.OverrideMetadata == nil) ||
+		(normalizeRuleSet(current.DefaultRuleSet) != nil && config.DefaultRuleSet == nil) ||
+		(normalizeRuleSet(current.OverrideRuleSet) != nil && config.OverrideRuleSet == nil)
+}
+
+// validateSubjectConfigRuleSets checks the default and override rule sets at plan time
+func validateSubjectConfigRuleSets(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"default_ruleset", "override_ruleset"} {
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		if err := ValidateRuleSet(ToRegistryRuleSet(d.Get(key).([]interface{}))); err != nil {
+			return fmt.Errorf("invalid '%s': %w", key, err)
+		}
+	}
+
+	return nil
+}
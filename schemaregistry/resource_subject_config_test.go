@@ -0,0 +1,175 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSubjectConfig_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckSubjectConfigDeleted(subject),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureSubjectConfig, subject, "BACKWARD"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "id", subject),
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "compatibility_level", "BACKWARD"),
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "override_metadata.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("schemaregistry_subject_config.test", "override_metadata.0.tags.*", map[string]string{
+						"path": "**.ssn",
+					}),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_subject_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Leaving out the override metadata clears it from the subject's config
+				Config: fmt.Sprintf(fixtureSubjectConfigLevelOnly, subject, "FULL"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "compatibility_level", "FULL"),
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "override_metadata.#", "0"),
+				),
+			},
+			{
+				// Leaving out the compatibility level clears it, so that the subject uses the global level
+				Config: fmt.Sprintf(fixtureSubjectConfigMetadataOnly, subject),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "compatibility_level", ""),
+					resource.TestCheckResourceAttr("schemaregistry_subject_config.test", "override_metadata.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSubjectConfigDeleted(subject string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		registry := testAccProvider.Meta().(*providerMeta).registry
+
+		if config, err := registry.GetSubjectConfig(context.Background(), subject, false); !isNotFound(err) {
+			return fmt.Errorf("expected subject %s to have no config, got %#v (%v)", subject, config, err)
+		}
+
+		return nil
+	}
+}
+
+func TestClearsSubjectConfig(t *testing.T) {
+	current := &SubjectConfig{CompatibilityLevel: "FULL", OverrideMetadata: &Metadata{Tags: map[string][]string{"**.ssn": {"PII"}}}}
+
+	tt := []struct {
+		name   string
+		config SubjectConfig
+		clears bool
+	}{
+		{name: "unchanged", config: *current},
+		{name: "level changed", config: SubjectConfig{CompatibilityLevel: "BACKWARD", OverrideMetadata: current.OverrideMetadata}},
+		{name: "level removed", config: SubjectConfig{OverrideMetadata: current.OverrideMetadata}, clears: true},
+		{name: "metadata removed", config: SubjectConfig{CompatibilityLevel: "FULL"}, clears: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := clearsSubjectConfig(current, tc.config); actual != tc.clears {
+				t.Errorf("expected clearsSubjectConfig to be %v", tc.clears)
+			}
+		})
+	}
+}
+
+func TestReplaceSubjectConfig(t *testing.T) {
+	current := &SubjectConfig{CompatibilityLevel: "FULL", CompatibilityGroup: "application.major.version"}
+	config := SubjectConfig{CompatibilityLevel: "FULL"}
+
+	tt := []struct {
+		name     string
+		config   SubjectConfig
+		failPuts int
+		expected []string
+		errors   []string
+	}{
+		{
+			name:     "replaced",
+			config:   config,
+			expected: []string{"DELETE", `PUT {"compatibility":"FULL"}`},
+		},
+		{
+			name:     "nothing kept",
+			expected: []string{"DELETE"},
+		},
+		{
+			name:     "restored",
+			config:   config,
+			failPuts: 1,
+			expected: []string{"DELETE", `PUT {"compatibility":"FULL"}`, `PUT {"compatibility":"FULL","compatibilityGroup":"application.major.version"}`},
+			errors:   []string{"Invalid compatibility level"},
+		},
+		{
+			name:     "restore failed",
+			config:   config,
+			failPuts: 2,
+			expected: []string{"DELETE", `PUT {"compatibility":"FULL"}`, `PUT {"compatibility":"FULL","compatibilityGroup":"application.major.version"}`},
+			errors:   []string{"Invalid compatibility level", "Restoring the previous config of subject orders-value failed"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			failPuts := tc.failPuts
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					calls = append(calls, r.Method)
+					w.Write([]byte(`{}`))
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				calls = append(calls, r.Method+" "+strings.TrimSpace(string(body)))
+				if failPuts > 0 {
+					failPuts--
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					w.Write([]byte(`{"error_code":42203,"message":"Invalid compatibility level"}`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			err := replaceSubjectConfig(context.Background(), newRegistryClient(server.URL, "", ""), "orders-value", current, tc.config)
+
+			if !reflect.DeepEqual(calls, tc.expected) {
+				t.Errorf("expected calls %v, got %v", tc.expected, calls)
+			}
+			if len(tc.errors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, expected := range tc.errors {
+				if err == nil || !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected an error containing %q, got %v", expected, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package schemaregistry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSubject_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureSubjectOneVersion, subject, fixtureAvro1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "id", subject),
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.#", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.0.version", "1"),
+					resource.TestCheckResourceAttrSet("schemaregistry_subject.test", "version.0.schema_id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(fixtureSubjectTwoVersions, subject, fixtureAvro1, fixtureAvro2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.#", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.0.version", "1"),
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.1.version", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.1.schema", strings.Replace(fixtureAvro2, "\\", "", -1)),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_subject.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSubject_drift(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := fmt.Sprintf("sub%s", u)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureSubjectTwoVersions, subject, fixtureAvro1, fixtureAvro2),
+				Check:  resource.TestCheckResourceAttr("schemaregistry_subject.test", "version.#", "2"),
+			},
+			{
+				Config:      fmt.Sprintf(fixtureSubjectTwoVersions, subject, fixtureAvro2, fixtureAvro2),
+				ExpectError: regexp.MustCompile(`version 1 of subject .* doesn't match version block 1`),
+			},
+		},
+	})
+}
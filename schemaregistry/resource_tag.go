@@ -0,0 +1,108 @@
+package schemaregistry
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// catalogEntityTypeAny is the entity type that lets a tag or business metadata apply to any entity
+const catalogEntityTypeAny = "cf_entity"
+
+func resourceTag() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: tagCreate,
+		UpdateContext: tagUpdate,
+		ReadContext:   tagRead,
+		DeleteContext: tagDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the tag, such as PII",
+				ForceNew:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "What the tag means",
+			},
+			"entity_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The types of entities the tag applies to, such as sr_schema or sr_field. Defaults to any entity",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func tagCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tagDef := tagDefFromResourceData(d)
+
+	if err := meta.(*providerMeta).registry.CreateTagDef(ctx, tagDef); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	d.SetId(tagDef.Name)
+
+	return tagRead(ctx, d, meta)
+}
+
+func tagUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := meta.(*providerMeta).registry.UpdateTagDef(ctx, tagDefFromResourceData(d)); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	return tagRead(ctx, d, meta)
+}
+
+func tagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagDef, err := meta.(*providerMeta).registry.GetTagDef(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("[WARN] Tag %s was deleted, removing it from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return registryDiagnostics(err, nil)
+	}
+
+	d.Set("name", tagDef.Name)
+	d.Set("description", tagDef.Description)
+
+	if err = d.Set("entity_types", tagDef.EntityTypes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func tagDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := meta.(*providerMeta).registry.DeleteTagDef(ctx, d.Id()); err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
+
+// tagDefFromResourceData builds the tag definition the resource configures
+func tagDefFromResourceData(d *schema.ResourceData) TagDef {
+	entityTypes := sortedStrings(d.Get("entity_types").(*schema.Set).List())
+	if len(entityTypes) == 0 {
+		entityTypes = []string{catalogEntityTypeAny}
+	}
+
+	return TagDef{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		EntityTypes: entityTypes,
+	}
+}
@@ -0,0 +1,99 @@
+package schemaregistry
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTagBinding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: tagBindingCreate,
+		ReadContext:   tagBindingRead,
+		DeleteContext: tagBindingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"entity_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of the tagged entity, such as sr_schema, sr_record, sr_field or sr_subject_version",
+				ForceNew:    true,
+			},
+			"entity_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The qualified name of the tagged entity, such as <cluster ID>:.:<schema ID> for a schema",
+				ForceNew:    true,
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the tag",
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func tagBindingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	binding := TagBinding{
+		TypeName:   d.Get("tag").(string),
+		EntityType: d.Get("entity_type").(string),
+		EntityName: d.Get("entity_name").(string),
+	}
+
+	if err := meta.(*providerMeta).registry.CreateTagBinding(ctx, binding); err != nil {
+		return registryDiagnostics(err, nil)
+	}
+	d.SetId(formatCatalogBindingID(binding.EntityType, binding.EntityName, binding.TypeName))
+
+	return tagBindingRead(ctx, d, meta)
+}
+
+func tagBindingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	entityType, entityName, tag, err := extractCatalogBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	bindings, err := meta.(*providerMeta).registry.GetTagBindings(ctx, entityType, entityName)
+	if err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	found := false
+	for _, binding := range bindings {
+		found = found || binding.TypeName == tag
+	}
+	if !found {
+		log.Printf("[WARN] Tag %s was removed from %s %s, removing it from state", tag, entityType, entityName)
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("entity_type", entityType)
+	d.Set("entity_name", entityName)
+	d.Set("tag", tag)
+
+	return diags
+}
+
+func tagBindingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	entityType, entityName, tag, err := extractCatalogBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	binding := TagBinding{TypeName: tag, EntityType: entityType, EntityName: entityName}
+	if err = meta.(*providerMeta).registry.DeleteTagBinding(ctx, binding); err != nil && !isNotFound(err) {
+		return registryDiagnostics(err, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package schemaregistry
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceTag_basic(t *testing.T) {
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tag names are identifiers, without dashes
+	tag := "tf" + strings.ReplaceAll(u, "-", "")[:12]
+	subject := fmt.Sprintf("sub%s", u)
+	clusterID := os.Getenv("SCHEMA_REGISTRY_CLUSTER_ID")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheckCatalog(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(fixtureTagBinding, tag, "Personal data", subject, fixtureAvro1, clusterID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_tag.test", "id", tag),
+					resource.TestCheckResourceAttr("schemaregistry_tag.test", "description", "Personal data"),
+					resource.TestCheckResourceAttr("schemaregistry_tag.test", "entity_types.#", "2"),
+					resource.TestCheckResourceAttr("schemaregistry_tag_binding.test", "tag", tag),
+					resource.TestCheckResourceAttrPair("schemaregistry_tag_binding.test", "tag", "schemaregistry_tag.test", "name"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(fixtureTagBinding, tag, "Personally identifiable information", subject, fixtureAvro1, clusterID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("schemaregistry_tag.test", "description", "Personally identifiable information"),
+				),
+			},
+			{
+				ResourceName:      "schemaregistry_tag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "schemaregistry_tag_binding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,24 +1,83 @@
 package schemaregistry
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"reflect"
+	"strconv"
 	"strings"
 
+	"github.com/ashleybill/srclient"
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
 	"github.com/bufbuild/protocompile/parser"
 	"github.com/bufbuild/protocompile/reporter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 )
 
 const IDSeparator = "___"
 
-func formatSchemaVersionID(subject string) string {
-	return subject
+// protoSchemaFileName is the path the schema being compiled is registered under in the import resolver
+const protoSchemaFileName = "schema.proto"
+
+// formatSchemaVersionID is the ID of a schema resource: the subject, followed by the version when pinned to one
+func formatSchemaVersionID(subject string, version int) string {
+	if version == 0 {
+		return subject
+	}
+	return subject + IDSeparator + strconv.Itoa(version)
 }
 
-func extractSchemaVersionID(id string) string {
-	return id
+// extractSchemaVersionID is the version a schema resource ID pins, 0 when the ID is just the subject. The subject is
+// taken from state rather than split off the ID, since subject names may contain the separator themselves.
+func extractSchemaVersionID(id string, subject string) (int, error) {
+	if id == subject {
+		return 0, nil
+	}
+
+	rawVersion, ok := strings.CutPrefix(id, subject+IDSeparator)
+	version, err := strconv.Atoi(rawVersion)
+	if !ok || err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid ID %q, expected %s or %s%s<version> with a positive version", id, subject, subject, IDSeparator)
+	}
+
+	return version, nil
+}
+
+// extractSchemaImportID splits a schema import ID into its subject and the version to pin, which is 0 when the ID is
+// just a subject. An ID is a pinned subject version when it ends with the separator and a positive version, and isn't
+// itself the name of an existing subject.
+func extractSchemaImportID(id string, subjectExists func(subject string) bool) (string, int) {
+	separator := strings.LastIndex(id, IDSeparator)
+	if separator < 0 {
+		return id, 0
+	}
+
+	version, err := strconv.Atoi(id[separator+len(IDSeparator):])
+	if err != nil || version < 1 || subjectExists(id) {
+		return id, 0
+	}
+
+	return id[:separator], version
+}
+
+// SchemasEquivalent compares two schema strings of the given type ignoring formatting: Avro and JSON schemas as JSON
+// documents, protobuf schemas by their AST
+func SchemasEquivalent(schemaType srclient.SchemaType, schemaString1 string, schemaString2 string) bool {
+	if schemaType == srclient.Protobuf {
+		schemaEquals, err := CompareASTs(schemaString1, schemaString2)
+		if err != nil {
+			// If theres an error diff should be true, indicating something is wrong?
+			log.Printf("[ERROR] schema %v", err)
+		}
+		return schemaEquals
+	}
+
+	json1, _ := structure.NormalizeJsonString(schemaString1)
+	json2, _ := structure.NormalizeJsonString(schemaString2)
+	return json1 == json2
 }
 
 // CompareASTs compares two AST nodes for equality
@@ -58,3 +117,75 @@ func protoStringToAST(protoSchemaString string) (parser.Result, error) {
 	}
 	return parser.ResultFromAST(res, true, errHandler)
 }
+
+// CompileProto fully compiles a protobuf schema, resolving its imports from the given map of import path to
+// schema text and from the bundled well-known types (google/protobuf/*.proto)
+func CompileProto(ctx context.Context, protoSchemaString string, imports map[string]string) (linker.File, error) {
+	sources := make(map[string]string, len(imports)+1)
+	for name, source := range imports {
+		sources[name] = source
+	}
+	sources[protoSchemaFileName] = protoSchemaString
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+
+	files, err := compiler.Compile(ctx, protoSchemaFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return files[0], nil
+}
+
+// ProtoImportsFromReferences fetches the referenced schemas, and the schemas they reference in turn, from the
+// registry and returns them keyed by reference name, which for protobuf is the import path
+func ProtoImportsFromReferences(client *srclient.SchemaRegistryClient, references []srclient.Reference) (map[string]string, error) {
+	imports := make(map[string]string)
+
+	var resolve func(references []srclient.Reference) error
+	resolve = func(references []srclient.Reference) error {
+		for _, reference := range references {
+			if _, ok := imports[reference.Name]; ok {
+				continue
+			}
+
+			referenced, err := client.GetSchemaByVersion(reference.Subject, reference.Version)
+			if err != nil {
+				return fmt.Errorf("error getting referenced schema %s (subject %s, version %d): %w", reference.Name, reference.Subject, reference.Version, err)
+			}
+
+			imports[reference.Name] = referenced.Schema()
+			if err = resolve(referenced.References()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := resolve(references); err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// formatCatalogBindingID is the ID of a tag or business metadata applied to an entity
+func formatCatalogBindingID(entityType string, entityName string, name string) string {
+	return strings.Join([]string{entityType, entityName, name}, IDSeparator)
+}
+
+// extractCatalogBindingID splits the ID of a binding. Entity types and tag names can't contain the separator, so the
+// entity name is whatever is between the first and the last one.
+func extractCatalogBindingID(id string) (string, string, string, error) {
+	first := strings.Index(id, IDSeparator)
+	last := strings.LastIndex(id, IDSeparator)
+	if first < 1 || last == first || last+len(IDSeparator) == len(id) {
+		return "", "", "", fmt.Errorf("invalid ID %s, expected <entity type>%s<entity name>%s<name>", id, IDSeparator, IDSeparator)
+	}
+
+	return id[:first], id[first+len(IDSeparator) : last], id[last+len(IDSeparator):], nil
+}
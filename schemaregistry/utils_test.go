@@ -1,8 +1,11 @@
 package schemaregistry
 
 import (
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/ashleybill/srclient"
 )
 
 func TestProtoCompileASTComparison(t *testing.T) {
@@ -93,3 +96,215 @@ func TestMalformedProtoFileFormatting(t *testing.T) {
 	}
 
 }
+
+func TestCompileProtoWithWellKnownTypes(t *testing.T) {
+	protoSchema := `syntax = "proto3";
+	package com.fetchrewards.locationservice.proto;
+
+	import "google/protobuf/timestamp.proto";
+
+	message FidoLocationTracker {
+	  string location_id = 1;
+	  google.protobuf.Timestamp tracked_at = 2;
+	}`
+
+	if _, err := CompileProto(context.Background(), protoSchema, nil); err != nil {
+		t.Errorf("expected well-known import to resolve, but got: %v", err)
+	}
+}
+
+func TestCompileProtoWithReferencedImport(t *testing.T) {
+	imports := map[string]string{
+		"location.proto": `syntax = "proto3";
+		package com.fetchrewards.locationservice.proto;
+
+		message Location {
+		  string location_id = 1;
+		}`,
+	}
+
+	protoSchema := `syntax = "proto3";
+	package com.fetchrewards.locationservice.proto;
+
+	import "location.proto";
+
+	message FidoLocationTracker {
+	  Location location = 1;
+	  string fido = 2;
+	}`
+
+	if _, err := CompileProto(context.Background(), protoSchema, imports); err != nil {
+		t.Errorf("expected referenced import to resolve, but got: %v", err)
+	}
+}
+
+func TestCompileProtoUnresolvedType(t *testing.T) {
+	protoSchema := `syntax = "proto3";
+	package com.fetchrewards.locationservice.proto;
+
+	message FidoLocationTracker {
+	  Location location = 1;
+	}`
+
+	_, err := CompileProto(context.Background(), protoSchema, nil)
+	if err == nil {
+		t.Fatal("expected unknown type to fail compilation")
+	}
+
+	if !strings.Contains(err.Error(), "Location") {
+		t.Errorf("expected error to mention the unresolved type, but got: %v", err)
+	}
+}
+
+func TestCompileProtoMissingImport(t *testing.T) {
+	protoSchema := `syntax = "proto3";
+	package com.fetchrewards.locationservice.proto;
+
+	import "location.proto";
+
+	message FidoLocationTracker {
+	  string fido = 1;
+	}`
+
+	if _, err := CompileProto(context.Background(), protoSchema, nil); err == nil {
+		t.Error("expected import without a reference to fail compilation")
+	}
+}
+
+func TestSchemasEquivalent(t *testing.T) {
+	tt := []struct {
+		name       string
+		schemaType srclient.SchemaType
+		schema1    string
+		schema2    string
+		equivalent bool
+	}{
+		{name: "avro formatting", schemaType: srclient.Avro, schema1: `{"type":"string"}`, schema2: "{\n  \"type\": \"string\"\n}", equivalent: true},
+		{name: "avro change", schemaType: srclient.Avro, schema1: `{"type":"string"}`, schema2: `{"type":"int"}`},
+		{name: "json formatting", schemaType: srclient.Json, schema1: `{"type":"object"}`, schema2: `{ "type": "object" }`, equivalent: true},
+		{name: "protobuf formatting", schemaType: srclient.Protobuf, schema1: "syntax = \"proto3\";\nmessage A {\n  string a = 1;\n}\n", schema2: "syntax = \"proto3\"; message A { string a = 1; }", equivalent: true},
+		{name: "protobuf change", schemaType: srclient.Protobuf, schema1: "syntax = \"proto3\";\nmessage A {\n  string a = 1;\n}\n", schema2: "syntax = \"proto3\";\nmessage A {\n  int32 a = 1;\n}\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if equivalent := SchemasEquivalent(tc.schemaType, tc.schema1, tc.schema2); equivalent != tc.equivalent {
+				t.Errorf("expected SchemasEquivalent to be %t, got %t", tc.equivalent, equivalent)
+			}
+		})
+	}
+}
+
+func TestExtractSchemaImportID(t *testing.T) {
+	// Subjects that exist in the registry, whose names look like pinned subject versions
+	existing := map[string]bool{"events___2": true}
+
+	tt := []struct {
+		id      string
+		subject string
+		version int
+	}{
+		{id: "subject", subject: "subject"},
+		{id: "subject___3", subject: "subject", version: 3},
+		{id: "my___subject___12", subject: "my___subject", version: 12},
+		{id: "orders___v2", subject: "orders___v2"},
+		{id: "events___2", subject: "events___2"},
+		{id: "events___2___1", subject: "events___2", version: 1},
+		{id: "subject___latest", subject: "subject___latest"},
+		{id: "subject___0", subject: "subject___0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.id, func(t *testing.T) {
+			subject, version := extractSchemaImportID(tc.id, func(subject string) bool { return existing[subject] })
+
+			if subject != tc.subject || version != tc.version {
+				t.Errorf("expected subject %q and version %d, got %q and %d", tc.subject, tc.version, subject, version)
+			}
+		})
+	}
+}
+
+func TestSchemaVersionID(t *testing.T) {
+	tt := []struct {
+		id      string
+		subject string
+		version int
+		isValid bool
+	}{
+		{id: "subject", subject: "subject", isValid: true},
+		{id: "subject___3", subject: "subject", version: 3, isValid: true},
+		{id: "my___subject___12", subject: "my___subject", version: 12, isValid: true},
+		{id: "events___2", subject: "events___2", isValid: true},
+		{id: "events___2___1", subject: "events___2", version: 1, isValid: true},
+		{id: "subject___latest", subject: "subject"},
+		{id: "subject___0", subject: "subject"},
+		{id: "other___1", subject: "subject"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.id, func(t *testing.T) {
+			version, err := extractSchemaVersionID(tc.id, tc.subject)
+
+			if !tc.isValid {
+				if err == nil {
+					t.Errorf("expected ID %q to be invalid", tc.id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected ID %q to be valid, but got: %v", tc.id, err)
+			}
+
+			if version != tc.version {
+				t.Errorf("expected version %d, got %d", tc.version, version)
+			}
+
+			if id := formatSchemaVersionID(tc.subject, version); id != tc.id {
+				t.Errorf("expected formatted ID %q, got %q", tc.id, id)
+			}
+		})
+	}
+}
+
+func TestCatalogBindingID(t *testing.T) {
+	tt := []struct {
+		id         string
+		entityType string
+		entityName string
+		name       string
+		isValid    bool
+	}{
+		{id: "sr_schema___lsrc-123:.:100001___PII", entityType: "sr_schema", entityName: "lsrc-123:.:100001", name: "PII", isValid: true},
+		{id: "sr_subject_version___lsrc-123:.:my___subject:1___PII", entityType: "sr_subject_version", entityName: "lsrc-123:.:my___subject:1", name: "PII", isValid: true},
+		{id: "sr_schema___PII"},
+		{id: "___lsrc-123:.:100001___PII"},
+		{id: "sr_schema___lsrc-123:.:100001___"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.id, func(t *testing.T) {
+			entityType, entityName, name, err := extractCatalogBindingID(tc.id)
+
+			if !tc.isValid {
+				if err == nil {
+					t.Errorf("expected ID %q to be invalid", tc.id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected ID %q to be valid, but got: %v", tc.id, err)
+			}
+
+			if entityType != tc.entityType || entityName != tc.entityName || name != tc.name {
+				t.Errorf("expected %q, %q and %q, got %q, %q and %q", tc.entityType, tc.entityName, tc.name, entityType, entityName, name)
+			}
+
+			if id := formatCatalogBindingID(entityType, entityName, name); id != tc.id {
+				t.Errorf("expected ID %q, got %q", tc.id, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/ashleybill/srclient"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linkedin/goavro/v2"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// jsonSchemaFileName is the URL the JSON schema being compiled is registered under in the compiler
+const jsonSchemaFileName = "schema.json"
+
+// SchemaError locates a parse or compile error within a schema string. Line and Column are one-based, and zero when
+// the parser can't tell where the error is.
+type SchemaError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SchemaError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateSchema parses and compiles a schema offline, without contacting the registry. Schemas with references can
+// only be checked for syntax, since the referenced types are resolved by the registry.
+func ValidateSchema(schemaType srclient.SchemaType, schemaString string, hasReferences bool) error {
+	switch schemaType {
+	case srclient.Json:
+		return validateJSONSchema(schemaString)
+	case srclient.Protobuf:
+		return validateProtobuf(schemaString, hasReferences)
+	default:
+		return validateAvro(schemaString, hasReferences)
+	}
+}
+
+func validateAvro(schemaString string, hasReferences bool) error {
+	if _, err := parseJSON(schemaString); err != nil {
+		return err
+	}
+
+	if hasReferences {
+		return nil
+	}
+
+	if _, err := goavro.NewCodec(schemaString); err != nil {
+		return &SchemaError{Err: err}
+	}
+
+	return nil
+}
+
+func validateJSONSchema(schemaString string) error {
+	if _, err := parseJSON(schemaString); err != nil {
+		return err
+	}
+
+	// Draft 7 is what the registry assumes when $schema is missing, other drafts are picked up from $schema
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	// External $refs point at referenced subjects, which are resolved by the registry
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("{}")), nil
+	}
+
+	if err := compiler.AddResource(jsonSchemaFileName, strings.NewReader(schemaString)); err != nil {
+		return &SchemaError{Err: err}
+	}
+
+	if _, err := compiler.Compile(jsonSchemaFileName); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return &SchemaError{Err: err}
+		}
+
+		for len(validationErr.Causes) > 0 {
+			validationErr = validationErr.Causes[0]
+		}
+
+		schemaErr := &SchemaError{Err: fmt.Errorf("%s: %s", quoteJSONPointer(validationErr.InstanceLocation), validationErr.Message)}
+		if offset, ok := jsonPointerOffset(schemaString, validationErr.InstanceLocation); ok {
+			schemaErr.Line, schemaErr.Column = offsetToPosition(schemaString, offset)
+		}
+		return schemaErr
+	}
+
+	return nil
+}
+
+func validateProtobuf(schemaString string, hasReferences bool) error {
+	var err error
+	if hasReferences {
+		// Imports can only be resolved against the registry, see validateProtobufSchema
+		_, err = protoStringToAST(schemaString)
+	} else {
+		_, err = CompileProto(context.Background(), schemaString, nil)
+	}
+
+	return protoSchemaError(err)
+}
+
+// validateSchemaString checks the syntax of a schema without knowing its type: JSON for Avro and JSON Schema, protobuf
+// otherwise. Type-aware checks run in the resource's CustomizeDiff, since validation functions only see one attribute.
+func validateSchemaString(i interface{}, path cty.Path) diag.Diagnostics {
+	schemaString, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of schema to be string")
+	}
+
+	var err error
+	if looksLikeJSON(schemaString) {
+		_, err = parseJSON(schemaString)
+	} else {
+		_, err = protoStringToAST(schemaString)
+		if err = protoSchemaError(err); err != nil {
+			err = fmt.Errorf("not valid JSON (Avro, JSON Schema) or protobuf: %w", err)
+		}
+	}
+
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid schema",
+				Detail:        err.Error(),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+// deleteModes are the values of delete_mode
+var deleteModes = []string{deleteModeSoft, deleteModeHard, deleteModeSoftThenHard, deleteModeAbandon, deleteModeRetain}
+
+// validateDeleteMode checks delete_mode is one of the supported modes
+func validateDeleteMode(i interface{}, path cty.Path) diag.Diagnostics {
+	mode, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of delete_mode to be string")
+	}
+
+	for _, deleteMode := range deleteModes {
+		if mode == deleteMode {
+			return nil
+		}
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid delete_mode",
+			Detail:        fmt.Sprintf("expected delete_mode to be one of %s, %s, %s, %s or %s, got %s", deleteModeSoft, deleteModeHard, deleteModeSoftThenHard, deleteModeAbandon, deleteModeRetain, mode),
+			AttributePath: path,
+		},
+	}
+}
+
+// warnDeletedVersionsOnReplace logs, at plan time, the versions replacing the resource, because subject or schema_type
+// changes, removes from the registry under its delete_mode. The SDK can't attach warnings to a plan, and doesn't run
+// CustomizeDiff for destroys, which report what they removed when applied instead.
+func warnDeletedVersionsOnReplace(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("subject") && !schemaTypeChangeReplaces(ctx, d, meta) {
+		return nil
+	}
+
+	subject, _ := d.GetChange("subject")
+	version, _ := extractSchemaVersionID(d.Id(), subject.(string))
+
+	logDeletedVersions(ctx, meta, subject.(string), version, d.Get("delete_mode").(string))
+
+	return nil
+}
+
+// logDeletedVersions logs the versions of the subject, or the pinned version, replacing a resource removes
+func logDeletedVersions(ctx context.Context, meta interface{}, subject string, version int, mode string) {
+	versions, err := versionsRemovedByDelete(ctx, meta.(*providerMeta).registry, subject, version, mode)
+	if err != nil {
+		log.Printf("[WARN] Could not list the versions replacing subject %s removes: %v", subject, err)
+		return
+	}
+	if len(versions) > 0 {
+		log.Printf("[WARN] Replacing subject %s %s versions %v of it, delete_mode is %s", subject, deleteModeEffect(mode), versions, mode)
+	}
+}
+
+// versionsRemovedByDelete lists the versions of the subject, or the pinned version, deleting the resource under the
+// delete_mode soft or permanently deletes
+func versionsRemovedByDelete(ctx context.Context, registry *registryClient, subject string, version int, mode string) ([]int, error) {
+	if mode == deleteModeAbandon || mode == deleteModeRetain {
+		return nil, nil
+	}
+
+	// soft only removes live versions, hard only soft deleted ones, and soft_then_hard both
+	versions, err := registry.GetVersions(ctx, subject, mode != deleteModeSoft)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == deleteModeHard {
+		live, err := registry.GetVersions(ctx, subject, false)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		versions = withoutVersions(versions, live)
+	}
+
+	if version > 0 {
+		for _, v := range versions {
+			if v == version {
+				return []int{version}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	return versions, nil
+}
+
+// withoutVersions is versions without the excluded ones
+func withoutVersions(versions []int, excluded []int) []int {
+	kept := make([]int, 0, len(versions))
+	for _, v := range versions {
+		found := false
+		for _, e := range excluded {
+			found = found || v == e
+		}
+		if !found {
+			kept = append(kept, v)
+		}
+	}
+
+	return kept
+}
+
+// deleteModeEffect describes what a delete_mode does to the versions it removes
+func deleteModeEffect(mode string) string {
+	if mode == deleteModeSoft {
+		return "soft deletes"
+	}
+	return "permanently deletes"
+}
+
+// validateSchemaDiff runs the offline, type-aware validation of ValidateSchema at plan time
+func validateSchemaDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("schema") || !d.NewValueKnown("schema_type") {
+		return nil
+	}
+
+	hasReferences := len(d.Get("reference").([]interface{})) > 0
+	if err := ValidateSchema(ToSchemaType(d.Get("schema_type")), d.Get("schema").(string), hasReferences); err != nil {
+		return fmt.Errorf("invalid 'schema': %w", err)
+	}
+
+	return nil
+}
+
+// validateRuleSetDiff checks the rule set at plan time, since the registry only rejects an invalid rule on apply
+func validateRuleSetDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ruleset") {
+		return nil
+	}
+
+	if err := ValidateRuleSet(ToRegistryRuleSet(d.Get("ruleset").([]interface{}))); err != nil {
+		return fmt.Errorf("invalid 'ruleset': %w", err)
+	}
+
+	return nil
+}
+
+// ValidateRuleSet checks what the block schema can't: rule names are unique, conditions have an expression,
+// transforms have an expression or tags selecting the fields they transform, and actions fit the rule's mode
+func ValidateRuleSet(ruleSet *RuleSet) error {
+	if ruleSet == nil {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for _, rule := range append(append([]Rule{}, ruleSet.DomainRules...), ruleSet.MigrationRules...) {
+		if names[rule.Name] {
+			return fmt.Errorf("rule name %s is used more than once", rule.Name)
+		}
+		names[rule.Name] = true
+
+		switch {
+		case rule.Kind == ruleKindCondition && rule.Expr == "":
+			return fmt.Errorf("rule %s is a %s, which needs an expr", rule.Name, ruleKindCondition)
+		case rule.Kind == ruleKindTransform && rule.Expr == "" && len(rule.Tags) == 0:
+			return fmt.Errorf("rule %s is a %s, which needs an expr or the tags of the fields it transforms", rule.Name, ruleKindTransform)
+		}
+
+		if err := validateRuleAction(rule.Mode, rule.OnSuccess); err != nil {
+			return fmt.Errorf("rule %s has an invalid on_success: %w", rule.Name, err)
+		}
+		if err := validateRuleAction(rule.Mode, rule.OnFailure); err != nil {
+			return fmt.Errorf("rule %s has an invalid on_failure: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRuleAction checks an on_success or on_failure action. Rules that run both ways, on write and read or on
+// upgrade and downgrade, can take a different action each way.
+func validateRuleAction(mode string, action string) error {
+	if action == "" {
+		return nil
+	}
+
+	actions := strings.Split(action, ",")
+	if len(actions) > 2 || (len(actions) == 2 && mode != "WRITEREAD" && mode != "UPDOWN") {
+		return fmt.Errorf("%s takes a pair of actions only for WRITEREAD and UPDOWN rules, not %s", action, mode)
+	}
+
+	for _, a := range actions {
+		valid := false
+		for _, ruleAction := range ruleActions {
+			valid = valid || a == ruleAction
+		}
+		if !valid {
+			return fmt.Errorf("%s is not one of %s", a, strings.Join(ruleActions, ", "))
+		}
+	}
+
+	return nil
+}
+
+func looksLikeJSON(schemaString string) bool {
+	trimmed := strings.TrimSpace(schemaString)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "\"")
+}
+
+func parseJSON(schemaString string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schemaString), &v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// Offset is the number of bytes read when the error occurred, so the offending byte is the one before it
+			line, column := offsetToPosition(schemaString, syntaxErr.Offset-1)
+			return nil, &SchemaError{Line: line, Column: column, Err: err}
+		}
+		return nil, &SchemaError{Err: err}
+	}
+	return v, nil
+}
+
+func protoSchemaError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var posErr reporter.ErrorWithPos
+	if errors.As(err, &posErr) {
+		pos := posErr.GetPosition()
+		return &SchemaError{Line: pos.Line, Column: pos.Col, Err: posErr.Unwrap()}
+	}
+
+	return &SchemaError{Err: err}
+}
+
+// offsetToPosition converts a zero-based byte offset into a one-based line and column
+func offsetToPosition(text string, offset int64) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > int64(len(text)) {
+		offset = int64(len(text))
+	}
+
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := int(offset) - strings.LastIndex(before, "\n")
+
+	return line, column
+}
+
+// jsonPointerOffset returns the byte offset of the value a JSON pointer (RFC 6901) refers to
+func jsonPointerOffset(document string, pointer string) (int64, bool) {
+	target := []string{}
+	if pointer != "" {
+		for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			target = append(target, strings.NewReplacer("~1", "/", "~0", "~").Replace(token))
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(document))
+
+	var walk func(path []string) (int64, bool, error)
+	walk = func(path []string) (int64, bool, error) {
+		offset := valueOffset(document, decoder.InputOffset())
+		matches := len(path) == len(target)
+		for i := 0; matches && i < len(path); i++ {
+			matches = path[i] == target[i]
+		}
+
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, false, err
+		}
+		if matches {
+			return offset, true, nil
+		}
+
+		switch token {
+		case json.Delim('{'):
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return 0, false, err
+				}
+				if found, ok, err := walk(append(path, key.(string))); ok || err != nil {
+					return found, ok, err
+				}
+			}
+			_, err = decoder.Token()
+		case json.Delim('['):
+			for i := 0; decoder.More(); i++ {
+				if found, ok, err := walk(append(path, strconv.Itoa(i))); ok || err != nil {
+					return found, ok, err
+				}
+			}
+			_, err = decoder.Token()
+		}
+		return 0, false, err
+	}
+
+	offset, ok, err := walk(nil)
+	return offset, ok && err == nil
+}
+
+// valueOffset skips the whitespace and separators the decoder hasn't consumed yet
+func valueOffset(document string, offset int64) int64 {
+	for offset < int64(len(document)) && strings.ContainsRune(" \t\r\n:,", rune(document[offset])) {
+		offset++
+	}
+	return offset
+}
+
+func quoteJSONPointer(pointer string) string {
+	if pointer == "" {
+		return "schema root"
+	}
+	return strconv.Quote(pointer)
+}
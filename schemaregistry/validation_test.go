@@ -0,0 +1,332 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashleybill/srclient"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tt := []struct {
+		name          string
+		schemaType    srclient.SchemaType
+		schema        string
+		hasReferences bool
+		line          int
+		column        int
+		errContains   string
+	}{
+		{
+			name:       "valid avro",
+			schemaType: srclient.Avro,
+			schema:     strings.Replace(fixtureAvro2, "\\", "", -1),
+		},
+		{
+			name:       "avro syntax error",
+			schemaType: srclient.Avro,
+			schema:     "{\n  \"type\": \"record\",\n  \"name\": \"userAdded\"\n  \"fields\": []\n}",
+			line:       4,
+			column:     3,
+		},
+		{
+			name:        "avro unknown type",
+			schemaType:  srclient.Avro,
+			schema:      `{"type":"record","name":"userAdded","fields":[{"name":"firstName","type":"strin"}]}`,
+			errContains: "strin",
+		},
+		{
+			name:          "avro with references only checks syntax",
+			schemaType:    srclient.Avro,
+			schema:        `["akc.test.userAdded"]`,
+			hasReferences: true,
+		},
+		{
+			name:       "valid json schema",
+			schemaType: srclient.Json,
+			schema:     `{"type":"object","properties":{"firstName":{"type":"string"}},"required":["firstName"]}`,
+		},
+		{
+			name:       "json schema draft from $schema",
+			schemaType: srclient.Json,
+			schema:     `{"$schema":"http://json-schema.org/draft-04/schema#","type":"integer","minimum":1,"exclusiveMinimum":true}`,
+		},
+		{
+			name:        "json schema defaults to draft 7",
+			schemaType:  srclient.Json,
+			schema:      `{"type":"integer","minimum":1,"exclusiveMinimum":true}`,
+			line:        1,
+			column:      50,
+			errContains: "/exclusiveMinimum",
+		},
+		{
+			name:        "json schema invalid keyword value",
+			schemaType:  srclient.Json,
+			schema:      "{\n  \"type\": \"object\",\n  \"properties\": {\n    \"firstName\": {\"type\": \"strin\"}\n  }\n}",
+			line:        4,
+			column:      27,
+			errContains: "/properties/firstName/type",
+		},
+		{
+			name:       "json schema with external reference",
+			schemaType: srclient.Json,
+			schema:     `{"type":"object","properties":{"user":{"$ref":"user.json"}}}`,
+		},
+		{
+			name:       "valid protobuf",
+			schemaType: srclient.Protobuf,
+			schema:     "syntax = \"proto3\";\nimport \"google/protobuf/timestamp.proto\";\nmessage A {\n  google.protobuf.Timestamp at = 1;\n}\n",
+		},
+		{
+			name:       "protobuf syntax error",
+			schemaType: srclient.Protobuf,
+			schema:     "syntax = \"proto3\";\nmessage A {\n  string a = ;\n}\n",
+			line:       3,
+			column:     14,
+		},
+		{
+			name:        "protobuf unknown type",
+			schemaType:  srclient.Protobuf,
+			schema:      "syntax = \"proto3\";\nmessage A {\n  Location location = 1;\n}\n",
+			line:        3,
+			column:      3,
+			errContains: "Location",
+		},
+		{
+			name:          "protobuf with references only checks syntax",
+			schemaType:    srclient.Protobuf,
+			schema:        "syntax = \"proto3\";\nimport \"location.proto\";\nmessage A {\n  Location location = 1;\n}\n",
+			hasReferences: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSchema(tc.schemaType, tc.schema, tc.hasReferences)
+
+			if tc.line == 0 && tc.errContains == "" {
+				if err != nil {
+					t.Fatalf("expected schema to be valid, but got: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected schema to be invalid")
+			}
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected a SchemaError, but got: %T %v", err, err)
+			}
+
+			if schemaErr.Line != tc.line || schemaErr.Column != tc.column {
+				t.Errorf("expected error at line %d, column %d, but got line %d, column %d: %v", tc.line, tc.column, schemaErr.Line, schemaErr.Column, err)
+			}
+
+			if !strings.Contains(err.Error(), tc.errContains) {
+				t.Errorf("expected error to contain %q, but got: %v", tc.errContains, err)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaString(t *testing.T) {
+	tt := []struct {
+		name    string
+		schema  string
+		isValid bool
+	}{
+		{name: "avro", schema: strings.Replace(fixtureAvro1, "\\", "", -1), isValid: true},
+		{name: "avro primitive", schema: `"string"`, isValid: true},
+		{name: "protobuf", schema: "syntax = \"proto3\";\nmessage A {\n  string a = 1;\n}\n", isValid: true},
+		{name: "broken json", schema: `{"type":"record",}`},
+		{name: "neither json nor protobuf", schema: "Not a schema"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateSchemaString(tc.schema, cty.GetAttrPath("schema"))
+
+			if tc.isValid && diags.HasError() {
+				t.Errorf("expected schema to be valid, but got: %v", diags)
+			}
+
+			if !tc.isValid && !diags.HasError() {
+				t.Error("expected schema to be invalid")
+			}
+		})
+	}
+}
+
+func TestValidateDeleteMode(t *testing.T) {
+	tt := []struct {
+		mode    string
+		isValid bool
+	}{
+		{mode: "soft", isValid: true},
+		{mode: "hard", isValid: true},
+		{mode: "soft_then_hard", isValid: true},
+		{mode: "abandon", isValid: true},
+		{mode: "retain", isValid: true},
+		{mode: "permanent"},
+		{mode: ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.mode, func(t *testing.T) {
+			diags := validateDeleteMode(tc.mode, cty.GetAttrPath("delete_mode"))
+
+			// What destroying the resource removes is only reported when a replace or destroy happens
+			if tc.isValid && len(diags) != 0 {
+				t.Errorf("expected no diagnostics, but got: %v", diags)
+			}
+
+			if !tc.isValid && !diags.HasError() {
+				t.Error("expected delete_mode to be invalid")
+			}
+		})
+	}
+}
+
+func TestVersionsRemovedByDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects/payments-value/versions" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+			return
+		}
+		// Versions 1 and 2 are soft deleted, 3 and 4 are live
+		if r.URL.Query().Get("deleted") == "true" {
+			w.Write([]byte(`[1,2,3,4]`))
+			return
+		}
+		w.Write([]byte(`[3,4]`))
+	}))
+	defer server.Close()
+
+	registry := newRegistryClient(server.URL, "", "")
+
+	tt := []struct {
+		mode     string
+		subject  string
+		version  int
+		expected []int
+	}{
+		{mode: deleteModeSoft, subject: "payments-value", expected: []int{3, 4}},
+		{mode: deleteModeHard, subject: "payments-value", expected: []int{1, 2}},
+		{mode: deleteModeSoftThenHard, subject: "payments-value", expected: []int{1, 2, 3, 4}},
+		{mode: deleteModeSoftThenHard, subject: "payments-value", version: 3, expected: []int{3}},
+		{mode: deleteModeAbandon, subject: "payments-value"},
+		{mode: deleteModeSoft, subject: "missing"},
+	}
+
+	for _, tc := range tt {
+		t.Run(fmt.Sprintf("%s/%s/%d", tc.mode, tc.subject, tc.version), func(t *testing.T) {
+			versions, err := versionsRemovedByDelete(context.Background(), registry, tc.subject, tc.version, tc.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(versions) != fmt.Sprint(tc.expected) && len(versions)+len(tc.expected) > 0 {
+				t.Errorf("expected versions %v, got %v", tc.expected, versions)
+			}
+		})
+	}
+}
+
+func TestValidateRuleSet(t *testing.T) {
+	condition := Rule{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL", Expr: "size(message.ssn) == 9"}
+
+	tt := []struct {
+		name    string
+		ruleSet *RuleSet
+		isValid bool
+	}{
+		{name: "none", isValid: true},
+		{name: "condition", ruleSet: &RuleSet{DomainRules: []Rule{condition}}, isValid: true},
+		{
+			name:    "condition without expr",
+			ruleSet: &RuleSet{DomainRules: []Rule{{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL"}}},
+		},
+		{
+			name:    "transform with tags",
+			ruleSet: &RuleSet{DomainRules: []Rule{{Name: "encrypt", Kind: ruleKindTransform, Mode: "WRITEREAD", Type: "ENCRYPT", Tags: []string{"PII"}}}},
+			isValid: true,
+		},
+		{
+			name:    "transform without expr or tags",
+			ruleSet: &RuleSet{DomainRules: []Rule{{Name: "encrypt", Kind: ruleKindTransform, Mode: "WRITEREAD", Type: "ENCRYPT"}}},
+		},
+		{
+			name: "duplicate name",
+			ruleSet: &RuleSet{
+				DomainRules:    []Rule{condition},
+				MigrationRules: []Rule{{Name: "checkSsn", Kind: ruleKindTransform, Mode: "UPGRADE", Type: "JSONATA", Expr: "$"}},
+			},
+		},
+		{
+			name:    "action pair both ways",
+			ruleSet: &RuleSet{MigrationRules: []Rule{{Name: "rename", Kind: ruleKindTransform, Mode: "UPDOWN", Type: "JSONATA", Expr: "$", OnFailure: "ERROR,DLQ"}}},
+			isValid: true,
+		},
+		{
+			name:    "action pair one way",
+			ruleSet: &RuleSet{DomainRules: []Rule{{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL", Expr: "true", OnFailure: "ERROR,DLQ"}}},
+		},
+		{
+			name:    "unknown action",
+			ruleSet: &RuleSet{DomainRules: []Rule{{Name: "checkSsn", Kind: ruleKindCondition, Mode: "WRITE", Type: "CEL", Expr: "true", OnSuccess: "RETRY"}}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRuleSet(tc.ruleSet)
+
+			if tc.isValid && err != nil {
+				t.Errorf("expected rule set to be valid, but got: %v", err)
+			}
+
+			if !tc.isValid && err == nil {
+				t.Error("expected rule set to be invalid")
+			}
+		})
+	}
+}
+
+func TestJSONPointerOffset(t *testing.T) {
+	document := `{"a": [1, {"b/c": true}], "d": "e"}`
+
+	tt := []struct {
+		pointer string
+		value   string
+	}{
+		{pointer: "", value: `{"a"`},
+		{pointer: "/a", value: "[1,"},
+		{pointer: "/a/1", value: `{"b/c"`},
+		{pointer: "/a/1/b~1c", value: "true"},
+		{pointer: "/d", value: `"e"`},
+	}
+
+	for _, tc := range tt {
+		offset, ok := jsonPointerOffset(document, tc.pointer)
+		if !ok {
+			t.Errorf("expected pointer %q to be found", tc.pointer)
+			continue
+		}
+
+		if !strings.HasPrefix(document[offset:], tc.value) {
+			t.Errorf("expected pointer %q to point at %q, but got %q", tc.pointer, tc.value, document[offset:])
+		}
+	}
+
+	if _, ok := jsonPointerOffset(document, "/missing"); ok {
+		t.Error("expected missing pointer not to be found")
+	}
+}